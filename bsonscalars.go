@@ -0,0 +1,240 @@
+package gographql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bsonScalars returns the scalars for the types of the bson primitive package.
+func bsonScalars() map[reflect.Type]*graphql.Scalar {
+	return map[reflect.Type]*graphql.Scalar{
+		reflect.TypeOf(primitive.DateTime(0)): BSONDateTime,
+		reflect.TypeOf(primitive.Timestamp{}): BSONTimestamp,
+		reflect.TypeOf(primitive.Binary{}):    BSONBinary,
+		reflect.TypeOf(primitive.Regex{}):     BSONRegex,
+		reflect.TypeOf(primitive.M{}):         BSONDocument,
+		reflect.TypeOf(primitive.D{}):         BSONOrderedDocument,
+		reflect.TypeOf(primitive.A{}):         BSONArray,
+	}
+}
+
+// extJSONKey is the key of the document that wraps values for encoding them to, and decoding them from, extended JSON.
+const extJSONKey = "v"
+
+// toExtJSON returns value encoded as relaxed MongoDB extended JSON; for example an ObjectID is {"$oid": "..."}.
+func toExtJSON(value interface{}) (document json.RawMessage, err error) {
+	wrapped, err := bson.MarshalExtJSON(primitive.D{{Key: extJSONKey, Value: value}}, false, false)
+	if nil != err {
+		return
+	}
+	var unwrapped map[string]json.RawMessage
+	if err = json.Unmarshal(wrapped, &unwrapped); nil != err {
+		return
+	}
+	return unwrapped[extJSONKey], nil
+}
+
+// fromExtJSON decodes the extended JSON document to a value of Type.
+func fromExtJSON(document []byte, Type reflect.Type) (value interface{}, err error) {
+	wrapped := append(append([]byte(`{"`+extJSONKey+`":`), document...), '}')
+	target := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "V",
+		Type: Type,
+		Tag:  reflect.StructTag(`bson:"` + extJSONKey + `"`),
+	}}))
+	if err = bson.UnmarshalExtJSON(wrapped, false, target.Interface()); nil != err {
+		return
+	}
+	return target.Elem().Field(0).Interface(), nil
+}
+
+// newExtJSONScalar returns a scalar that serializes values of Type as relaxed extended JSON and parses them from it.
+// Literals are written as JSON values; the order of the keys of object literals is kept.  Since graphql names cannot
+// have a "$", values having extended JSON keys, such as {"$oid": "..."}, are also parsed from strings of JSON text.
+func newExtJSONScalar(name, description string, Type reflect.Type) *graphql.Scalar {
+	parse := func(document []byte) interface{} {
+		value, err := fromExtJSON(document, Type)
+		if nil != err {
+			log.Infof("%v: %v", name, err)
+			return nil
+		}
+		return value
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        name,
+		Description: description,
+		Serialize: func(value interface{}) interface{} {
+			source := reflect.ValueOf(value)
+			if reflect.Ptr == source.Kind() && !source.IsNil() {
+				source = source.Elem()
+			}
+			if !source.IsValid() || source.Type() != Type {
+				return nil
+			}
+			document, err := toExtJSON(source.Interface())
+			if nil != err {
+				log.Errorf("%v: %v", name, err)
+				return nil
+			}
+			return document
+		},
+		ParseValue: func(value interface{}) interface{} {
+			if text, ok := value.(string); ok {
+				return parse([]byte(text))
+			}
+			document, err := json.Marshal(value)
+			if nil != err {
+				return nil
+			}
+			return parse(document)
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if text, ok := valueAST.(*ast.StringValue); ok {
+				return parse([]byte(text.Value))
+			}
+			var document bytes.Buffer
+			if err := writeJSONFromAST(&document, valueAST); nil != err {
+				log.Infof("%v: %v", name, err)
+				return nil
+			}
+			return parse(document.Bytes())
+		},
+	})
+}
+
+// writeJSONFromAST writes the JSON text of a literal, keeping the order of the keys of objects.
+func writeJSONFromAST(document *bytes.Buffer, valueAST ast.Value) (err error) {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		document.WriteString("{")
+		for i, field := range valueAST.Fields {
+			if 0 < i {
+				document.WriteString(",")
+			}
+			document.WriteString(quote(field.Name.Value) + ":")
+			if err = writeJSONFromAST(document, field.Value); nil != err {
+				return
+			}
+		}
+		document.WriteString("}")
+	case *ast.ListValue:
+		document.WriteString("[")
+		for i, value := range valueAST.Values {
+			if 0 < i {
+				document.WriteString(",")
+			}
+			if err = writeJSONFromAST(document, value); nil != err {
+				return
+			}
+		}
+		document.WriteString("]")
+	case *ast.IntValue:
+		document.WriteString(valueAST.Value)
+	case *ast.FloatValue:
+		document.WriteString(valueAST.Value)
+	case *ast.StringValue:
+		document.WriteString(quote(valueAST.Value))
+	case *ast.BooleanValue:
+		fmt.Fprint(document, valueAST.Value)
+	case *ast.EnumValue:
+		document.WriteString(quote(valueAST.Value))
+	default:
+		err = fmt.Errorf("cannot translate %T to JSON", valueAST)
+	}
+	return
+}
+
+func parseBSONDateTime(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value))
+		if nil != err {
+			log.Infof("BSONDateTime: %v", err)
+			return nil
+		}
+		return primitive.NewDateTimeFromTime(t)
+	case *ast.StringValue:
+		return parseBSONDateTime(value.Value)
+	case *ast.IntValue:
+		ms, err := toInt64(value.Value)
+		if nil != err {
+			return nil
+		}
+		return primitive.DateTime(ms)
+	}
+	ms, err := toInt64(value)
+	if nil != err {
+		log.Infof("BSONDateTime: %v", err)
+		return nil
+	}
+	return primitive.DateTime(ms)
+}
+
+// BSONDateTime reflects the bson DateTime to a graphql type and vice versa.  It is serialized as an RFC 3339 string,
+// and parses RFC 3339 strings and numbers of milliseconds since the Unix epoch.
+var BSONDateTime = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "BSONDateTime",
+	Description: "A BSON date and time.  It is serialized as an RFC 3339 string, and parsed from one or from milliseconds since the Unix epoch.",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case primitive.DateTime:
+			return value.Time().UTC().Format(time.RFC3339Nano)
+		case *primitive.DateTime:
+			if nil != value {
+				return value.Time().UTC().Format(time.RFC3339Nano)
+			}
+		}
+		return nil
+	},
+	ParseValue: parseBSONDateTime,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return parseBSONDateTime(valueAST)
+	},
+})
+
+// BSONTimestamp reflects the bson Timestamp to a graphql type and vice versa, as {"$timestamp": {"t": 1, "i": 2}}.
+var BSONTimestamp = newExtJSONScalar(
+	"BSONTimestamp", `A BSON timestamp, as extended JSON; {"$timestamp": {"t": seconds, "i": increment}}.`,
+	reflect.TypeOf(primitive.Timestamp{}),
+)
+
+// BSONBinary reflects the bson Binary to a graphql type and vice versa, as {"$binary": {"base64": "...", "subType": "00"}}.
+var BSONBinary = newExtJSONScalar(
+	"BSONBinary", `BSON binary data, as extended JSON; {"$binary": {"base64": data, "subType": hexadecimal subtype}}.`,
+	reflect.TypeOf(primitive.Binary{}),
+)
+
+// BSONRegex reflects the bson Regex to a graphql type and vice versa, as {"$regularExpression": {"pattern": "...", "options": "..."}}.
+var BSONRegex = newExtJSONScalar(
+	"BSONRegex", `A BSON regular expression, as extended JSON; {"$regularExpression": {"pattern": pattern, "options": options}}.`,
+	reflect.TypeOf(primitive.Regex{}),
+)
+
+// BSONDocument reflects the bson M to a graphql type and vice versa, as a relaxed extended JSON object.
+var BSONDocument = newExtJSONScalar(
+	"BSONDocument", "A BSON document, as a relaxed extended JSON object.",
+	reflect.TypeOf(primitive.M{}),
+)
+
+// BSONOrderedDocument reflects the bson D to a graphql type and vice versa, as a relaxed extended JSON object.
+// The order of the keys is kept when the document is serialized and when it is parsed from a literal; the keys of
+// variables are ordered by name.
+var BSONOrderedDocument = newExtJSONScalar(
+	"BSONOrderedDocument", "A BSON document having ordered keys, as a relaxed extended JSON object.",
+	reflect.TypeOf(primitive.D{}),
+)
+
+// BSONArray reflects the bson A to a graphql type and vice versa, as a relaxed extended JSON array.
+var BSONArray = newExtJSONScalar(
+	"BSONArray", "A BSON array, as a relaxed extended JSON array.",
+	reflect.TypeOf(primitive.A{}),
+)
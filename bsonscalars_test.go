@@ -0,0 +1,91 @@
+package gographql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBSONDateTime(t *testing.T) {
+	moment := primitive.NewDateTimeFromTime(time.Date(2021, 3, 4, 5, 6, 7, 8000000, time.UTC))
+	testSerialize(t, BSONDateTime, []scalarTest{
+		{moment, "2021-03-04T05:06:07.008Z"},
+		{&moment, "2021-03-04T05:06:07.008Z"},
+		{(*primitive.DateTime)(nil), nil},
+		{time.Now(), nil},
+	})
+	testParse(t, BSONDateTime, []scalarTest{
+		{"2021-03-04T05:06:07.008Z", "2021-03-04T05:06:07.008Z"},
+		{" 2021-03-04T07:06:07+02:00 ", "2021-03-04T05:06:07Z"},
+		{1614834367008, "2021-03-04T05:06:07.008Z"},
+		{float64(0), "1970-01-01T00:00:00Z"},
+		{&ast.StringValue{Value: "2021-03-04T05:06:07Z"}, "2021-03-04T05:06:07Z"},
+		{&ast.IntValue{Value: "-1000"}, "1969-12-31T23:59:59Z"},
+		{"March 4th", nil},
+		{1.5, nil},
+		{&ast.IntValue{Value: "99999999999999999999"}, nil},
+		{&ast.BooleanValue{Value: true}, nil},
+	})
+}
+
+func TestBSONExtJSONScalars(t *testing.T) {
+	id := objectID(t, "000000000000000000000001")
+	testSerialize(t, BSONTimestamp, []scalarTest{
+		{primitive.Timestamp{T: 1, I: 2}, `{"$timestamp":{"t":1,"i":2}}`},
+		{&primitive.Timestamp{T: 3}, `{"$timestamp":{"t":3,"i":0}}`},
+		{(*primitive.Timestamp)(nil), nil},
+		{1, nil},
+	})
+	testParse(t, BSONTimestamp, []scalarTest{
+		{`{"$timestamp": {"t": 1, "i": 2}}`, `{"$timestamp":{"t":1,"i":2}}`},
+		{map[string]interface{}{"$timestamp": map[string]interface{}{"t": 5, "i": 6}}, `{"$timestamp":{"t":5,"i":6}}`},
+		{&ast.StringValue{Value: `{"$timestamp": {"t": 1, "i": 2}}`}, `{"$timestamp":{"t":1,"i":2}}`},
+		{`{"t": 1}`, nil},
+		{"{", nil},
+	})
+	testSerialize(t, BSONBinary, []scalarTest{
+		{primitive.Binary{Subtype: 0, Data: []byte("hi")}, `{"$binary":{"base64":"aGk=","subType":"00"}}`},
+	})
+	testParse(t, BSONBinary, []scalarTest{
+		{`{"$binary": {"base64": "aGk=", "subType": "80"}}`, `{"$binary":{"base64":"aGk=","subType":"80"}}`},
+		{`{"$binary": {"base64": "!", "subType": "00"}}`, nil},
+	})
+	testSerialize(t, BSONRegex, []scalarTest{
+		{primitive.Regex{Pattern: "^a", Options: "i"}, `{"$regularExpression":{"pattern":"^a","options":"i"}}`},
+	})
+	testParse(t, BSONRegex, []scalarTest{
+		{`{"$regularExpression": {"pattern": "b$", "options": ""}}`, `{"$regularExpression":{"pattern":"b$","options":""}}`},
+		{`"b$"`, nil},
+	})
+	testSerialize(t, BSONDocument, []scalarTest{
+		{primitive.M{"_id": id}, `{"_id":{"$oid":"000000000000000000000001"}}`},
+		{primitive.D{{Key: "a", Value: 1}}, nil},
+	})
+	testParse(t, BSONDocument, []scalarTest{
+		{map[string]interface{}{"n": 1.5}, `{"n":1.5}`},
+		{`{"_id": {"$oid": "000000000000000000000001"}}`, `{"_id":{"$oid":"000000000000000000000001"}}`},
+		{&ast.ObjectValue{Fields: []*ast.ObjectField{{Name: &ast.Name{Value: "n"}, Value: &ast.IntValue{Value: "2"}}}}, `{"n":2}`},
+		{`[1]`, nil},
+	})
+	testSerialize(t, BSONOrderedDocument, []scalarTest{
+		{primitive.D{{Key: "z", Value: 1}, {Key: "a", Value: "b"}}, `{"z":1,"a":"b"}`},
+	})
+	testParse(t, BSONOrderedDocument, []scalarTest{
+		{&ast.ObjectValue{Fields: []*ast.ObjectField{
+			{Name: &ast.Name{Value: "z"}, Value: &ast.ListValue{Values: []ast.Value{&ast.BooleanValue{Value: true}, &ast.EnumValue{Value: "E"}}}},
+			{Name: &ast.Name{Value: "a"}, Value: &ast.FloatValue{Value: "0.5"}},
+		}}, `{"z":[true,"E"],"a":0.5}`},
+		{map[string]interface{}{"z": 1, "a": 2}, `{"a":2,"z":1}`},
+		{&ast.ObjectValue{Fields: []*ast.ObjectField{{Name: &ast.Name{Value: "v"}, Value: &ast.Variable{Name: &ast.Name{Value: "x"}}}}}, nil},
+	})
+	testSerialize(t, BSONArray, []scalarTest{
+		{primitive.A{1, "a", id}, `[1,"a",{"$oid":"000000000000000000000001"}]`},
+	})
+	testParse(t, BSONArray, []scalarTest{
+		{[]interface{}{1, "a"}, `[1,"a"]`},
+		{&ast.ListValue{Values: []ast.Value{&ast.IntValue{Value: "1"}}}, `[1]`},
+		{`{"a": 1}`, nil},
+	})
+}
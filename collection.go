@@ -0,0 +1,674 @@
+package gographql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/sssmack/gographql/internal/bsonvalue"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// A Collection provides the finds that the generated query fields of a registered collection run.
+// MongoCollection adapts a *mongo.Collection to it; the memcollection package has a fake that holds documents in memory.
+// Filters are bson documents whose keys are bson field paths.
+type Collection interface {
+	// FindOne decodes the first document that matches the filter into the value that result points to.
+	// It returns mongo.ErrNoDocuments when no document matches.
+	FindOne(ctx context.Context, filter interface{}, result interface{}) error
+	// Find decodes the documents that match the filter, in the order and the range of opts, into the slice that results points to.
+	Find(ctx context.Context, filter interface{}, opts *options.FindOptions, results interface{}) error
+	// CountDocuments returns the number of documents that match the filter.
+	CountDocuments(ctx context.Context, filter interface{}) (int64, error)
+}
+
+// MongoCollection adapts a *mongo.Collection to a Collection.
+type MongoCollection struct {
+	Collection *mongo.Collection
+}
+
+// FindOne decodes the first document that matches the filter into result.
+func (mc MongoCollection) FindOne(ctx context.Context, filter interface{}, result interface{}) error {
+	return mc.Collection.FindOne(ctx, filter).Decode(result)
+}
+
+// Find decodes the documents that match the filter into results.
+func (mc MongoCollection) Find(ctx context.Context, filter interface{}, opts *options.FindOptions, results interface{}) (err error) {
+	cursor, err := mc.Collection.Find(ctx, filter, opts)
+	if nil != err {
+		return
+	}
+	return cursor.All(ctx, results)
+}
+
+// CountDocuments returns the number of documents that match the filter.
+func (mc MongoCollection) CountDocuments(ctx context.Context, filter interface{}) (int64, error) {
+	return mc.Collection.CountDocuments(ctx, filter)
+}
+
+// collectionBinding binds the Go struct of the documents of a collection to the collection.
+type collectionBinding struct {
+	Type       reflect.Type
+	collection Collection
+	paths      map[string]documentPath
+	idName     string
+}
+
+// documentPath is the bson path, and the Go type, of the graphql field, or of the dotted path of nested graphql fields, of a document.
+// index is the index of the struct field in the struct that declares, or embeds, it.
+type documentPath struct {
+	bson  string
+	Type  reflect.Type
+	index []int
+}
+
+// RegisterCollection binds the Go struct of documents, given as a value, a pointer to one, or its reflect.Type, to a collection.
+// A SchemaBuilder adds query fields for each registered collection; for the struct Host they are
+//
+//	hostById(id: ID!): Host
+//	hosts(filter: JSON, where: Host_Filter, orderBy: [Host_OrderBy!], sort: [String!], first: Int, after: ID): [Host]
+//	hostsConnection(filter: JSON, where: Host_Filter, orderBy: [Host_OrderBy!], sort: [String!], first: Int, after: String, last: Int, before: String): HostConnection!
+//	hostCount(filter: JSON, where: Host_Filter): Int!
+//
+// where ID is the type of the field that has the bson key "_id".  See README.md for their arguments.
+func RegisterCollection(goStruct interface{}, collection Collection) (err error) {
+	return objectMapper.RegisterCollection(goStruct, collection)
+}
+
+// RegisterCollection binds the Go struct of documents, given as a value, a pointer to one, or its reflect.Type, to a collection.
+// A SchemaBuilder adds query fields for each registered collection; for the struct Host they are
+//
+//	hostById(id: ID!): Host
+//	hosts(filter: JSON, where: Host_Filter, orderBy: [Host_OrderBy!], sort: [String!], first: Int, after: ID): [Host]
+//	hostsConnection(filter: JSON, where: Host_Filter, orderBy: [Host_OrderBy!], sort: [String!], first: Int, after: String, last: Int, before: String): HostConnection!
+//	hostCount(filter: JSON, where: Host_Filter): Int!
+//
+// where ID is the type of the field that has the bson key "_id".  See README.md for their arguments.
+func (tm *typeMapper) RegisterCollection(goStruct interface{}, collection Collection) (err error) {
+	if nil == collection {
+		return errors.New("the collection cannot be nil")
+	}
+	Type, err := structType(goStruct)
+	if nil != err {
+		return
+	}
+	for _, binding := range tm.collections {
+		if binding.Type == Type {
+			return fmt.Errorf("%v is already registered with a collection", Type)
+		}
+	}
+	binding := &collectionBinding{Type: Type, collection: collection, paths: map[string]documentPath{}}
+	tm.documentPaths(Type, "", "", binding.paths, map[reflect.Type]bool{})
+	for name, path := range binding.paths {
+		if "_id" == path.bson {
+			binding.idName = name
+		}
+	}
+	if "" == binding.idName {
+		return fmt.Errorf(`%v has no field having the bson key "_id"`, Type)
+	}
+	tm.collections = append(tm.collections, binding)
+	return
+}
+
+// structType returns the struct type of a struct given as a value, a pointer to one, or its reflect.Type.
+func structType(goStruct interface{}) (Type reflect.Type, err error) {
+	Type, ok := goStruct.(reflect.Type)
+	if !ok {
+		Type = reflect.TypeOf(goStruct)
+	}
+	if nil != Type && reflect.Ptr == Type.Kind() {
+		Type = Type.Elem()
+	}
+	if nil == Type || reflect.Struct != Type.Kind() {
+		err = fmt.Errorf("%v is not a struct", Type)
+	}
+	return
+}
+
+// structPaths returns the paths of the fields of structure, by the names of their graphql fields.
+func (tm *typeMapper) structPaths(structure reflect.Type) (paths map[string]documentPath) {
+	paths = map[string]documentPath{}
+	for _, structField := range flattenedFields(structure) {
+		if "" != structField.PkgPath {
+			continue
+		}
+		graphqlName, skip := tm.fieldName(structField)
+		if skip {
+			continue
+		}
+		bsonName, skip := bsonPath(structure, structField.Index)
+		if skip {
+			continue
+		}
+		paths[graphqlName] = documentPath{bson: bsonName, Type: indirectType(structField.Type), index: structField.Index}
+	}
+	return
+}
+
+// nestedStruct returns the struct that the field of Type nests, directly or as the elements of a slice or array;
+// nil when it nests none.
+func (tm *typeMapper) nestedStruct(Type reflect.Type) reflect.Type {
+	if reflect.Slice == Type.Kind() || reflect.Array == Type.Kind() {
+		if nil != tm.registeredScalar(Type) {
+			return nil
+		}
+		Type = indirectType(Type.Elem())
+	}
+	if reflect.Struct != Type.Kind() || nil != tm.registeredScalar(Type) {
+		return nil
+	}
+	return Type
+}
+
+// documentPaths adds the paths of the fields of structure, and of the fields of the structs that they nest, to paths.
+func (tm *typeMapper) documentPaths(structure reflect.Type, graphqlPrefix, bsonPrefix string, paths map[string]documentPath, visited map[reflect.Type]bool) {
+	if visited[structure] {
+		return
+	}
+	visited[structure] = true
+	defer delete(visited, structure)
+	for graphqlName, path := range tm.structPaths(structure) {
+		graphqlName = joinPath(graphqlPrefix, graphqlName)
+		path.bson = joinPath(bsonPrefix, path.bson)
+		paths[graphqlName] = path
+		if nested := tm.nestedStruct(path.Type); nil != nested {
+			tm.documentPaths(nested, graphqlName, path.bson, paths, visited)
+		}
+	}
+}
+
+// indirectType returns the type that pointers of Type point to, or Type when it is not a pointer.
+func indirectType(Type reflect.Type) reflect.Type {
+	for reflect.Ptr == Type.Kind() {
+		Type = Type.Elem()
+	}
+	return Type
+}
+
+// bsonPath returns the bson path of the field of structure at index, which may lead through embedded structs.
+// skip is true when the bson tag of the field, or of a struct it is embedded in, is "-".
+func bsonPath(structure reflect.Type, index []int) (path string, skip bool) {
+	var segments []string
+	Type := structure
+	for _, i := range index {
+		Type = indirectType(Type)
+		structField := Type.Field(i)
+		tag := structField.Tag.Get("bson")
+		key := tagName(tag)
+		if "-" == key {
+			return "", true
+		}
+		if !strings.Contains(tag, ",inline") {
+			if "" == key {
+				key = strings.ToLower(structField.Name)
+			}
+			segments = append(segments, key)
+		}
+		Type = structField.Type
+	}
+	return strings.Join(segments, "."), false
+}
+
+// collectionFields returns the query fields of the registered collections.
+func (tm *typeMapper) collectionFields() (fields graphql.Fields, errs SchemaErrors) {
+	fields = graphql.Fields{}
+	for _, binding := range tm.collections {
+		bindingFields, err := tm.bindingFields(binding)
+		if nil != err {
+			errs = append(errs, fmt.Errorf("collection of %v: %v", binding.Type, err))
+			continue
+		}
+		for name, field := range bindingFields {
+			if _, exists := fields[name]; exists {
+				errs = append(errs, fmt.Errorf(`collection of %v: the query field "%v" is generated for another collection`, binding.Type, name))
+				continue
+			}
+			fields[name] = field
+		}
+	}
+	return
+}
+
+func (tm *typeMapper) bindingFields(binding *collectionBinding) (fields graphql.Fields, err error) {
+	object, err := tm.GoToGraphqlOutput(binding.Type)
+	if nil != err {
+		return
+	}
+	idField, ok := object.Fields()[binding.idName]
+	if !ok {
+		err = fmt.Errorf(`%v has no field named "%v"`, object.Name(), binding.idName)
+		return
+	}
+	idType, ok := graphql.GetNamed(idField.Type).(graphql.Input)
+	if !ok {
+		err = fmt.Errorf(`the type of the id field "%v", %v, is not an input type`, binding.idName, graphql.GetNamed(idField.Type))
+		return
+	}
+	name := lowerCamel(object.Name())
+	filterArg := &graphql.ArgumentConfig{
+		Type:        JSON,
+		Description: "The documents having these field values; the keys are the names of fields, or Mongo query operators.",
+	}
+	whereArg := &graphql.ArgumentConfig{
+		Type:        tm.filterInput(binding.Type, object),
+		Description: "The documents that match the filter; they also match the filter argument.",
+	}
+	orderByArgs, err := tm.orderByArgs(binding.Type)
+	if nil != err {
+		return
+	}
+	findArgs := graphql.FieldConfigArgument{
+		"filter":  filterArg,
+		"where":   whereArg,
+		"orderBy": orderByArgs["orderBy"],
+		"sort": &graphql.ArgumentConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+			Description: `The names of the fields to order by; a name prefixed with "-" orders by the field descending.`,
+		},
+		"first": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "The number of documents to return at most.",
+		},
+		"after": &graphql.ArgumentConfig{
+			Type:        idType,
+			Description: "The id of the document after which to return documents; the last document of the previous page.",
+		},
+	}
+	fields = graphql.Fields{
+		name + "ById": &graphql.Field{
+			Name:        name + "ById",
+			Type:        object,
+			Description: fmt.Sprintf("The %v having the id.", object.Name()),
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(idType)},
+			},
+			Resolve: tm.resolveByID(binding),
+		},
+		plural(name): &graphql.Field{
+			Name:        plural(name),
+			Type:        graphql.NewList(wrapNonNull(object, tm.nonNull(binding.Type, ""))),
+			Description: fmt.Sprintf("The %v documents that match the filter.", object.Name()),
+			Args:        findArgs,
+			Resolve:     tm.resolveFind(binding),
+		},
+		plural(name) + "Connection": &graphql.Field{
+			Name:        plural(name) + "Connection",
+			Type:        graphql.NewNonNull(tm.connectionObject(object.Name(), object)),
+			Description: fmt.Sprintf("A page of the %v documents that match the filter.", object.Name()),
+			Args:        collectionConnectionArgs(findArgs),
+			Resolve:     tm.resolveCollectionConnection(binding),
+		},
+		name + "Count": &graphql.Field{
+			Name:        name + "Count",
+			Type:        graphql.NewNonNull(graphql.Int),
+			Description: fmt.Sprintf("The number of %v documents that match the filter.", object.Name()),
+			Args:        graphql.FieldConfigArgument{"filter": filterArg, "where": whereArg},
+			Resolve:     tm.resolveCount(binding),
+		},
+	}
+	return
+}
+
+// plural returns the English plural of the lower camel case name.
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "z"),
+		strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	case 1 < len(name) && strings.HasSuffix(name, "y") && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
+
+func resolveContext(p graphql.ResolveParams) context.Context {
+	if nil == p.Context {
+		return context.Background()
+	}
+	return p.Context
+}
+
+// idValue returns the id given as an argument as a value of the type of the id field.
+func (tm *typeMapper) idValue(binding *collectionBinding, id interface{}) (value interface{}, err error) {
+	return tm.coerce(binding.idName, id, binding.paths[binding.idName].Type)
+}
+
+func (tm *typeMapper) resolveByID(binding *collectionBinding) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		id, err := tm.idValue(binding, p.Args["id"])
+		if nil != err {
+			return
+		}
+		document := reflect.New(binding.Type)
+		err = binding.collection.FindOne(resolveContext(p), bson.D{{Key: "_id", Value: id}}, document.Interface())
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		if nil != err {
+			return
+		}
+		return document.Elem().Interface(), nil
+	}
+}
+
+func (tm *typeMapper) resolveFind(binding *collectionBinding) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		ctx := resolveContext(p)
+		filter, err := tm.bindingFilter(binding, p.Args)
+		if nil != err {
+			return
+		}
+		sort, err := tm.sortDocument(binding, p.Args)
+		if nil != err {
+			return
+		}
+		opts := options.Find().SetSort(sort)
+		if first, ok := p.Args["first"].(int); ok {
+			if first < 0 {
+				return nil, fmt.Errorf("first cannot be negative; it is %v", first)
+			}
+			if 0 == first {
+				// A limit of 0 is no limit.
+				return reflect.MakeSlice(reflect.SliceOf(binding.Type), 0, 0).Interface(), nil
+			}
+			opts.SetLimit(int64(first))
+		}
+		if after, ok := p.Args["after"]; ok && nil != after {
+			var keyset bson.D
+			if keyset, err = tm.afterFilter(ctx, binding, sort, after); nil != err {
+				return
+			}
+			filter = andFilters(filter, keyset)
+		}
+		documents := reflect.New(reflect.SliceOf(binding.Type))
+		if err = binding.collection.Find(ctx, filter, opts, documents.Interface()); nil != err {
+			return
+		}
+		return documents.Elem().Interface(), nil
+	}
+}
+
+func (tm *typeMapper) resolveCount(binding *collectionBinding) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		filter, err := tm.bindingFilter(binding, p.Args)
+		if nil != err {
+			return
+		}
+		count, err := binding.collection.CountDocuments(resolveContext(p), filter)
+		if nil != err {
+			return
+		}
+		return int(count), nil
+	}
+}
+
+// bindingFilter returns the filter of the filter and where arguments.
+func (tm *typeMapper) bindingFilter(binding *collectionBinding, args map[string]interface{}) (filter bson.D, err error) {
+	filter, err = tm.translateFilter(binding, args["filter"])
+	if nil != err {
+		return
+	}
+	where, err := tm.filterToBSON("where", binding.Type, "", args["where"])
+	if nil != err {
+		return
+	}
+	return andFilters(filter, where), nil
+}
+
+// sortDocument returns the sort document of the orderBy argument followed by the names of the sort argument, ending
+// with _id so that the order is total.
+func (tm *typeMapper) sortDocument(binding *collectionBinding, args map[string]interface{}) (sort bson.D, err error) {
+	orderBy, err := tm.OrderByToBSON(binding.Type, args["orderBy"])
+	if nil != err {
+		return
+	}
+	sorted := map[string]bool{}
+	for _, key := range orderBy {
+		if !sorted[key.Key] {
+			sorted[key.Key] = true
+			sort = append(sort, key)
+		}
+	}
+	list, _ := args["sort"].([]interface{})
+	for _, name := range list {
+		fieldName, _ := name.(string)
+		direction := 1
+		if strings.HasPrefix(fieldName, "-") {
+			fieldName = fieldName[1:]
+			direction = -1
+		}
+		path, ok := binding.paths[fieldName]
+		if !ok {
+			return nil, fmt.Errorf(`cannot sort by "%v"; there is no such field`, fieldName)
+		}
+		if sorted[path.bson] {
+			continue
+		}
+		sorted[path.bson] = true
+		sort = append(sort, bson.E{Key: path.bson, Value: direction})
+	}
+	if !sorted["_id"] {
+		sort = append(sort, bson.E{Key: "_id", Value: 1})
+	}
+	return
+}
+
+// afterFilter returns the filter of the documents that follow the document having the id after, in the order of sort.
+func (tm *typeMapper) afterFilter(ctx context.Context, binding *collectionBinding, sort bson.D, after interface{}) (keyset bson.D, err error) {
+	id, err := tm.idValue(binding, after)
+	if nil != err {
+		return
+	}
+	var document bson.D
+	if err = binding.collection.FindOne(ctx, bson.D{{Key: "_id", Value: id}}, &document); nil != err {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = fmt.Errorf("after: there is no document having the id %v", after)
+		}
+		return
+	}
+	values := make([]interface{}, len(sort))
+	for i, key := range sort {
+		values[i], _ = bsonvalue.LookupFirst(document, key.Key)
+	}
+	return keysetFilter(sort, values), nil
+}
+
+// keysetFilter returns the filter of the documents that follow, in the order of sort, a document having the values of the sort keys.
+// Null, and missing, values order before all others, as they do in Mongo, and so $gt and $lt, which never match null, are
+// not used with them; a descending key is followed by null values, and nothing follows a null value.
+func keysetFilter(sort bson.D, values []interface{}) bson.D {
+	clauses := bson.A{}
+	for i, key := range sort {
+		clause := bson.D{}
+		for j := 0; j < i; j++ {
+			clause = append(clause, bson.E{Key: sort[j].Key, Value: values[j]})
+		}
+		switch {
+		case -1 != key.Value && nil == values[i]:
+			clause = append(clause, bson.E{Key: key.Key, Value: bson.D{{Key: "$ne", Value: nil}}})
+		case -1 != key.Value:
+			clause = append(clause, bson.E{Key: key.Key, Value: bson.D{{Key: "$gt", Value: values[i]}}})
+		case nil == values[i]:
+			continue
+		default:
+			clause = append(clause, bson.E{Key: "$or", Value: bson.A{
+				bson.D{{Key: key.Key, Value: bson.D{{Key: "$lt", Value: values[i]}}}},
+				bson.D{{Key: key.Key, Value: nil}},
+			}})
+		}
+		clauses = append(clauses, clause)
+	}
+	if 0 == len(clauses) {
+		// No document follows; $or cannot be empty.
+		return bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: bson.A{}}}}}
+	}
+	return bson.D{{Key: "$or", Value: clauses}}
+}
+
+// andFilters returns the filter of the documents that match both filters.
+func andFilters(filter, other bson.D) bson.D {
+	switch {
+	case 0 == len(filter):
+		return other
+	case 0 == len(other):
+		return filter
+	}
+	return bson.D{{Key: "$and", Value: bson.A{filter, other}}}
+}
+
+// translateFilter returns the filter argument having the names of graphql fields translated to bson paths, and the values
+// converted to the Go types of the fields.  Only the operators $and, $or and $nor, and those that translateCondition
+// allows, are kept; other operators, such as $where and $expr, are errors.
+func (tm *typeMapper) translateFilter(binding *collectionBinding, filter interface{}) (translated bson.D, err error) {
+	translated = bson.D{}
+	if nil == filter {
+		return
+	}
+	object, ok := filter.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("filter: got %T; expected an object", filter)
+	}
+	for _, key := range sortedKeys(object) {
+		value := object[key]
+		switch {
+		case "$and" == key, "$or" == key, "$nor" == key:
+			list, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("filter: %v takes a list of filters", key)
+			}
+			filters := bson.A{}
+			for _, element := range list {
+				elementFilter, err := tm.translateFilter(binding, element)
+				if nil != err {
+					return nil, err
+				}
+				filters = append(filters, elementFilter)
+			}
+			value = filters
+		case strings.HasPrefix(key, "$"):
+			return nil, fmt.Errorf("filter: the operator %v is not allowed", key)
+		default:
+			path, ok := binding.paths[key]
+			if !ok {
+				return nil, fmt.Errorf(`filter: there is no field named "%v"`, key)
+			}
+			if value, err = tm.translateCondition(key, value, path.Type); nil != err {
+				return
+			}
+			key = path.bson
+		}
+		translated = append(translated, bson.E{Key: key, Value: value})
+	}
+	return
+}
+
+// translateCondition converts the values of the condition on the field at path to the Go type of the field.
+// The condition is a value, which a field equals, or an object of Mongo query operators.
+// The operators are those of comparison, $in, $nin, $all, $exists, $regex with $options, and $not; others are errors.
+func (tm *typeMapper) translateCondition(path string, condition interface{}, Type reflect.Type) (translated interface{}, err error) {
+	operators, ok := condition.(map[string]interface{})
+	if !ok || 0 == len(operators) || !strings.HasPrefix(sortedKeys(operators)[0], "$") {
+		return tm.coerceCondition(path, condition, Type)
+	}
+	document := bson.D{}
+	for _, operator := range sortedKeys(operators) {
+		value := operators[operator]
+		switch operator {
+		case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+			value, err = tm.coerceCondition(path, value, Type)
+		case "$in", "$nin", "$all":
+			list, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("filter: %v: %v takes a list", path, operator)
+			}
+			values := bson.A{}
+			for _, element := range list {
+				coerced, err := tm.coerceCondition(path, element, Type)
+				if nil != err {
+					return nil, err
+				}
+				values = append(values, coerced)
+			}
+			value = values
+		case "$not":
+			value, err = tm.translateCondition(path, value, Type)
+		case "$exists":
+			if _, ok := value.(bool); !ok {
+				return nil, fmt.Errorf("filter: %v: %v takes a boolean", path, operator)
+			}
+		case "$regex", "$options":
+			if _, ok := value.(string); !ok {
+				return nil, fmt.Errorf("filter: %v: %v takes a string", path, operator)
+			}
+		default:
+			return nil, fmt.Errorf("filter: %v: the operator %v is not allowed", path, operator)
+		}
+		if nil != err {
+			return
+		}
+		document = append(document, bson.E{Key: operator, Value: value})
+	}
+	return document, nil
+}
+
+// coerceCondition converts value to the Go type of the field; to the type of its elements when the field is a slice
+// and value is not a list, since Mongo matches the elements of arrays.
+func (tm *typeMapper) coerceCondition(path string, value interface{}, Type reflect.Type) (coerced interface{}, err error) {
+	if _, isList := value.([]interface{}); !isList && nil == tm.registeredScalar(Type) &&
+		(reflect.Slice == Type.Kind() || reflect.Array == Type.Kind()) {
+		Type = indirectType(Type.Elem())
+	}
+	return tm.coerce(path, value, Type)
+}
+
+// coerce converts value, given as a variable or a JSON literal, to a value of Type.
+func (tm *typeMapper) coerce(path string, value interface{}, Type reflect.Type) (coerced interface{}, err error) {
+	if nil == value || reflect.TypeOf(value) == Type {
+		return value, nil
+	}
+	if scalar := tm.registeredScalar(Type); nil != scalar {
+		parsed := scalar.ParseValue(value)
+		if nil == parsed {
+			return nil, fmt.Errorf("%v: %v is not a valid %v", path, value, scalar.Name())
+		}
+		value = parsed
+	}
+	if number, ok := value.(json.Number); ok {
+		if value, err = number.Int64(); nil != err {
+			if value, err = number.Float64(); nil != err {
+				return nil, fmt.Errorf("%v: %v", path, err)
+			}
+		}
+	}
+	if enum := tm.enums[Type]; nil != enum {
+		if name, ok := value.(string); ok {
+			for _, enumValue := range enum.Values() {
+				if name == enumValue.Name {
+					value = enumValue.Value
+				}
+			}
+		}
+	}
+	target := reflect.New(Type).Elem()
+	if err = tm.decodeValue(path, value, target, nil); nil != err {
+		return
+	}
+	return target.Interface(), nil
+}
+
+// sortedKeys returns the keys of the object in lexical order, so that translated filters are deterministic.
+func sortedKeys(object map[string]interface{}) (keys []string) {
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
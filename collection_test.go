@@ -0,0 +1,214 @@
+package gographql
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/sssmack/gographql/memcollection"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Location struct {
+	City string `bson:"city"`
+}
+
+type Server struct {
+	ID       primitive.ObjectID `bson:"_id"`
+	Name     string             `bson:"name"`
+	Cpus     int                `bson:"cpus"`
+	Rank     *int               `bson:"rank,omitempty"`
+	Location Location           `bson:"location"`
+}
+
+func objectID(t *testing.T, hex string) primitive.ObjectID {
+	id, err := primitive.ObjectIDFromHex(hex)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// servers returns the documents of the tests; the ids order them as they are listed.
+func servers(t *testing.T) []interface{} {
+	return []interface{}{
+		Server{ID: objectID(t, "000000000000000000000001"), Name: "a", Cpus: 2, Location: Location{City: "Oslo"}},
+		Server{ID: objectID(t, "000000000000000000000002"), Name: "b", Cpus: 4, Location: Location{City: "Lima"}},
+		Server{ID: objectID(t, "000000000000000000000003"), Name: "c", Cpus: 1, Location: Location{City: "Oslo"}},
+		Server{ID: objectID(t, "000000000000000000000004"), Name: "d", Cpus: 4, Location: Location{City: "Rome"}},
+	}
+}
+
+// collectionSchema returns the mapper and the schema of a memcollection.Collection of Server documents.
+func collectionSchema(t *testing.T, documents ...interface{}) (tm typeMapper, schema graphql.Schema) {
+	collection, err := memcollection.New(documents...)
+	if nil != err {
+		t.Fatal(err)
+	}
+	tm = NewTypeMapper()
+	if err = tm.RegisterCollection(Server{}, collection); nil != err {
+		t.Fatal(err)
+	}
+	if schema, err = tm.NewSchemaBuilder().Build(); nil != err {
+		t.Fatal(err)
+	}
+	return
+}
+
+// execute runs the request and returns its data as JSON; it fails the test when the request has errors.
+func execute(t *testing.T, schema graphql.Schema, request string, variables map[string]interface{}) string {
+	t.Helper()
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: request, VariableValues: variables})
+	if result.HasErrors() {
+		t.Fatalf("%v: %v", request, result.Errors)
+	}
+	data, err := json.Marshal(result.Data)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// executeError runs the request and returns its first error; it fails the test when the request has no errors.
+func executeError(t *testing.T, schema graphql.Schema, request string, variables map[string]interface{}) string {
+	t.Helper()
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: request, VariableValues: variables})
+	if !result.HasErrors() {
+		t.Fatalf("%v: expected an error", request)
+	}
+	return result.Errors[0].Message
+}
+
+func TestCollectionByID(t *testing.T) {
+	_, schema := collectionSchema(t, servers(t)...)
+	for request, expected := range map[string]string{
+		`{serverById(id: "000000000000000000000002") {Name Location {City}}}`: `{"serverById":{"Location":{"City":"Lima"},"Name":"b"}}`,
+		`{serverById(id: "0000000000000000000000ff") {Name}}`:                 `{"serverById":null}`,
+	} {
+		if data := execute(t, schema, request, nil); expected != data {
+			t.Errorf("%v: got %v; expected %v", request, data, expected)
+		}
+	}
+	if message := executeError(t, schema, `{serverById(id: "x") {Name}}`, nil); !strings.Contains(message, "id") {
+		t.Errorf("got %q; expected an error about the id", message)
+	}
+}
+
+func TestCollectionFind(t *testing.T) {
+	_, schema := collectionSchema(t, servers(t)...)
+	for _, test := range []struct {
+		request   string
+		variables map[string]interface{}
+		expected  string
+	}{
+		{`{servers {Name}}`, nil, `{"servers":[{"Name":"a"},{"Name":"b"},{"Name":"c"},{"Name":"d"}]}`},
+		{`{servers(filter: {Cpus: 4}) {Name}}`, nil, `{"servers":[{"Name":"b"},{"Name":"d"}]}`},
+		{
+			`query($filter: JSON) {servers(filter: $filter) {Name}}`,
+			map[string]interface{}{"filter": map[string]interface{}{"Location.City": "Oslo", "Cpus": map[string]interface{}{"$gt": 1}}},
+			`{"servers":[{"Name":"a"}]}`,
+		},
+		{`{servers(where: {Location: {City: {eq: "Oslo"}}}) {Name}}`, nil, `{"servers":[{"Name":"a"},{"Name":"c"}]}`},
+		{`{servers(filter: {Cpus: 4}, where: {Name: {ne: "b"}}) {Name}}`, nil, `{"servers":[{"Name":"d"}]}`},
+		{`{servers(sort: ["-Cpus", "Name"]) {Name}}`, nil, `{"servers":[{"Name":"b"},{"Name":"d"},{"Name":"a"},{"Name":"c"}]}`},
+		{`{servers(orderBy: [{field: Cpus, direction: DESC}], sort: ["-Name"]) {Name}}`, nil, `{"servers":[{"Name":"d"},{"Name":"b"},{"Name":"a"},{"Name":"c"}]}`},
+		{`{servers(sort: ["Cpus"], first: 2) {Name}}`, nil, `{"servers":[{"Name":"c"},{"Name":"a"}]}`},
+		{`{servers(sort: ["Cpus"], first: 2, after: "000000000000000000000001") {Name}}`, nil, `{"servers":[{"Name":"b"},{"Name":"d"}]}`},
+		{`{servers(first: 0) {Name}}`, nil, `{"servers":[]}`},
+	} {
+		if data := execute(t, schema, test.request, test.variables); test.expected != data {
+			t.Errorf("%v: got %v; expected %v", test.request, data, test.expected)
+		}
+	}
+	for _, test := range []struct {
+		request   string
+		variables map[string]interface{}
+		expected  string
+	}{
+		{`{servers(first: -1) {Name}}`, nil, "first cannot be negative"},
+		{`{servers(sort: ["Disk"]) {Name}}`, nil, `cannot sort by "Disk"`},
+		{`{servers(after: "0000000000000000000000ff") {Name}}`, nil, "there is no document"},
+		{
+			`query($filter: JSON) {servers(filter: $filter) {Name}}`,
+			map[string]interface{}{"filter": map[string]interface{}{"$where": "sleep(1000)"}},
+			"the operator $where is not allowed",
+		},
+	} {
+		if message := executeError(t, schema, test.request, test.variables); !strings.Contains(message, test.expected) {
+			t.Errorf("%v: got %q; expected %q", test.request, message, test.expected)
+		}
+	}
+}
+
+func TestCollectionCount(t *testing.T) {
+	_, schema := collectionSchema(t, servers(t)...)
+	for request, expected := range map[string]string{
+		`{serverCount}`:                                         `{"serverCount":4}`,
+		`{serverCount(filter: {Cpus: 4})}`:                      `{"serverCount":2}`,
+		`{serverCount(where: {Cpus: {in: [1, 2]}})}`:            `{"serverCount":2}`,
+		`{serverCount(where: {not: {Name: {regex: "^[ab]"}}})}`: `{"serverCount":2}`,
+	} {
+		if data := execute(t, schema, request, nil); expected != data {
+			t.Errorf("%v: got %v; expected %v", request, data, expected)
+		}
+	}
+}
+
+func TestTranslateFilter(t *testing.T) {
+	tm, _ := collectionSchema(t)
+	binding := tm.collections[0]
+	translated, err := tm.translateFilter(binding, map[string]interface{}{
+		"Name":          map[string]interface{}{"$in": []interface{}{"a", "b"}, "$exists": true},
+		"Location.City": map[string]interface{}{"$regex": "^O", "$options": "i"},
+		"$or": []interface{}{
+			map[string]interface{}{"Cpus": json.Number("2")},
+			map[string]interface{}{"Cpus": map[string]interface{}{"$not": map[string]interface{}{"$lt": 4}}},
+		},
+		"ID": "000000000000000000000001",
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "cpus", Value: 2}},
+			bson.D{{Key: "cpus", Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$lt", Value: 4}}}}}},
+		}},
+		{Key: "_id", Value: objectID(t, "000000000000000000000001")},
+		{Key: "location.city", Value: bson.D{{Key: "$options", Value: "i"}, {Key: "$regex", Value: "^O"}}},
+		{Key: "name", Value: bson.D{{Key: "$exists", Value: true}, {Key: "$in", Value: bson.A{"a", "b"}}}},
+	}
+	if !reflect.DeepEqual(expected, translated) {
+		t.Errorf("got %v; expected %v", translated, expected)
+	}
+	for _, filter := range []map[string]interface{}{
+		{"$where": "true"},
+		{"$expr": map[string]interface{}{"$eq": []interface{}{"$name", "a"}}},
+		{"Name": map[string]interface{}{"$function": map[string]interface{}{}}},
+		{"Name": map[string]interface{}{"$not": map[string]interface{}{"$where": "true"}}},
+		{"Name": map[string]interface{}{"$eq": "a", "$accumulator": 1}},
+		{"Disk": 1},
+	} {
+		if _, err = tm.translateFilter(binding, filter); nil == err {
+			t.Errorf("%v: expected an error", filter)
+		}
+	}
+}
+
+func TestSortDocument(t *testing.T) {
+	tm, _ := collectionSchema(t)
+	sort, err := tm.sortDocument(tm.collections[0], map[string]interface{}{
+		"orderBy": []interface{}{map[string]interface{}{"field": "Cpus", "direction": -1}},
+		"sort":    []interface{}{"Cpus", "-Name", "Location.City"},
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := bson.D{{Key: "cpus", Value: -1}, {Key: "name", Value: -1}, {Key: "location.city", Value: 1}, {Key: "_id", Value: 1}}
+	if !reflect.DeepEqual(expected, sort) {
+		t.Errorf("got %v; expected %v", sort, expected)
+	}
+}
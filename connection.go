@@ -0,0 +1,337 @@
+package gographql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// ConnectionTag is the name of the key for a field tag key/value pair where the value "true" translates a list field to
+// a connection, which pages through the list, and "false" keeps it a list.
+var ConnectionTag = "connection"
+
+// offsetCursorPrefix prefixes the offsets that the cursors of connections of lists encode.
+const offsetCursorPrefix = "offset:"
+
+// PageInfo is the page information of connections, unless SetPageInfoName names it otherwise.
+var PageInfo = newPageInfo("PageInfo")
+
+// newPageInfo returns the page information object of connections, named name.
+func newPageInfo(name string) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        name,
+		Description: "Information about a page of a connection.",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(pageInfo).hasNextPage, nil
+				},
+			},
+			"hasPreviousPage": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(pageInfo).hasPreviousPage, nil
+				},
+			},
+			"startCursor": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalCursor(p.Source.(pageInfo).startCursor), nil
+				},
+			},
+			"endCursor": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optionalCursor(p.Source.(pageInfo).endCursor), nil
+				},
+			},
+		},
+	})
+}
+
+type pageInfo struct {
+	hasNextPage     bool
+	hasPreviousPage bool
+	startCursor     string
+	endCursor       string
+}
+
+type edge struct {
+	node   interface{}
+	cursor string
+}
+
+// connection is a page of a connection.  totalCount is a function so that the values are only counted when it is queried.
+type connection struct {
+	edges      []edge
+	pageInfo   pageInfo
+	totalCount func() (int, error)
+}
+
+// newConnection returns the page of the edges, having the start and end cursors of the edges.
+func newConnection(edges []edge, info pageInfo, totalCount func() (int, error)) connection {
+	if 0 != len(edges) {
+		info.startCursor = edges[0].cursor
+		info.endCursor = edges[len(edges)-1].cursor
+	}
+	return connection{edges: edges, pageInfo: info, totalCount: totalCount}
+}
+
+func optionalCursor(cursor string) interface{} {
+	if "" == cursor {
+		return nil
+	}
+	return cursor
+}
+
+// SetConnectionsByDefault sets whether list fields of output types are translated to connections when they are not tagged.
+// It is false by default.
+func SetConnectionsByDefault(connections bool) {
+	objectMapper.SetConnectionsByDefault(connections)
+}
+
+// SetConnectionsByDefault sets whether list fields of output types are translated to connections when they are not tagged.
+// It is false by default.
+func (tm *typeMapper) SetConnectionsByDefault(connections bool) {
+	tm.connectionsByDefault = connections
+}
+
+// SetPageInfoName sets the name of the page information object of connections, for schemas that have a type of their own
+// named PageInfo.  It is "PageInfo" by default.
+func SetPageInfoName(name string) {
+	objectMapper.SetPageInfoName(name)
+}
+
+// SetPageInfoName sets the name of the page information object of connections, for schemas that have a type of their own
+// named PageInfo.  It is "PageInfo" by default.
+func (tm *typeMapper) SetPageInfoName(name string) {
+	if PageInfo.Name() == name {
+		tm.pageInfo = PageInfo
+		return
+	}
+	tm.pageInfo = newPageInfo(name)
+}
+
+// pageInfoErrors returns an error when there are connections and another type has the name of their page information.
+func (tm *typeMapper) pageInfoErrors() (errs SchemaErrors) {
+	if !tm.pageInfoUsed {
+		return
+	}
+	if _, taken := tm.graphqlTypes[tm.pageInfo.Name()]; taken {
+		errs = append(errs, fmt.Errorf(`the page information of connections is named "%v", which is the name of another type; name it otherwise with SetPageInfoName`, tm.pageInfo.Name()))
+	}
+	return
+}
+
+// isConnection returns whether the field is translated to a connection.
+func (tm *typeMapper) isConnection(structField reflect.StructField) bool {
+	switch structField.Tag.Get(ConnectionTag) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if !tm.connectionsByDefault {
+		return false
+	}
+	Type := indirectType(structField.Type)
+	return (reflect.Slice == Type.Kind() || reflect.Array == Type.Kind()) && nil == tm.registeredScalar(Type)
+}
+
+// connectionArgs returns the arguments of connection fields.
+func connectionArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "The number of edges to return at most, from the start of the page.",
+		},
+		"after": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "The cursor of the edge after which the page starts.",
+		},
+		"last": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "The number of edges to return at most, from the end of the page.",
+		},
+		"before": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "The cursor of the edge before which the page ends.",
+		},
+	}
+}
+
+// connectionType returns the <Type>Connection object of the list type listType.  It is NonNull when listType is.
+func (tm *typeMapper) connectionType(listType graphql.Type) (connectionType graphql.Type, err error) {
+	nonNull, isNonNull := listType.(*graphql.NonNull)
+	if isNonNull {
+		listType = nonNull.OfType
+	}
+	list, ok := listType.(*graphql.List)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a list", listType)
+	}
+	nodeType := list.OfType
+	if elementType, ok := nodeType.(*graphql.NonNull); ok {
+		nodeType = elementType.OfType
+	}
+	if _, ok := nodeType.(*graphql.List); ok {
+		return nil, fmt.Errorf("%v is a list of lists", list)
+	}
+	name := nodeType.Name()
+	if words := reStub.FindStringSubmatch(name); nil != words {
+		name = words[1]
+	}
+	return wrapNonNull(tm.connectionObject(name, nodeType), isNonNull), nil
+}
+
+// connectionObject returns the <name>Connection object, having edges of the <name>Edge object, whose nodes are of nodeType.
+func (tm *typeMapper) connectionObject(name string, nodeType graphql.Type) *graphql.Object {
+	if object, ok := tm.graphqlTypes[name+"Connection"].(*graphql.Object); ok {
+		return object
+	}
+	tm.pageInfoUsed = true
+	edgeObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Edge",
+		Description: fmt.Sprintf("An edge of a %vConnection.", name),
+		Fields: graphql.Fields{
+			"node": &graphql.Field{
+				Type: nodeType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(edge).node, nil
+				},
+			},
+			"cursor": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(edge).cursor, nil
+				},
+			},
+		},
+	})
+	connectionObject := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Connection",
+		Description: fmt.Sprintf("A page of %v values.", name),
+		Fields: graphql.Fields{
+			"edges": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeObject))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(connection).edges, nil
+				},
+			},
+			"pageInfo": &graphql.Field{
+				Type: graphql.NewNonNull(tm.pageInfo),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(connection).pageInfo, nil
+				},
+			},
+			"totalCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "The number of values in all of the pages.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(connection).totalCount()
+				},
+			},
+		},
+	})
+	tm.graphqlTypes[edgeObject.Name()] = edgeObject
+	tm.graphqlTypes[connectionObject.Name()] = connectionObject
+	return connectionObject
+}
+
+func encodeOffsetCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(offsetCursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeOffsetCursor(cursor string) (offset int, err error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if nil != err || !strings.HasPrefix(string(decoded), offsetCursorPrefix) {
+		return 0, fmt.Errorf("%q is not a valid cursor", cursor)
+	}
+	if offset, err = strconv.Atoi(strings.TrimPrefix(string(decoded), offsetCursorPrefix)); nil != err || offset < 0 {
+		return 0, fmt.Errorf("%q is not a valid cursor", cursor)
+	}
+	return
+}
+
+// pageBounds returns the range of the list of length that the first, after, last and before arguments select.
+func pageBounds(length int, args map[string]interface{}) (start, end int, err error) {
+	end = length
+	if after, ok := args["after"].(string); ok {
+		offset, err := decodeOffsetCursor(after)
+		if nil != err {
+			return 0, 0, fmt.Errorf("after: %v", err)
+		}
+		if offset+1 > start {
+			start = offset + 1
+		}
+	}
+	if before, ok := args["before"].(string); ok {
+		offset, err := decodeOffsetCursor(before)
+		if nil != err {
+			return 0, 0, fmt.Errorf("before: %v", err)
+		}
+		if offset < end {
+			end = offset
+		}
+	}
+	if start > end {
+		start = end
+	}
+	if first, ok := args["first"].(int); ok {
+		if first < 0 {
+			return 0, 0, fmt.Errorf("first cannot be negative; it is %v", first)
+		}
+		if start+first < end {
+			end = start + first
+		}
+	}
+	if last, ok := args["last"].(int); ok {
+		if last < 0 {
+			return 0, 0, fmt.Errorf("last cannot be negative; it is %v", last)
+		}
+		if end-last > start {
+			start = end - last
+		}
+	}
+	return
+}
+
+// resolveConnection returns a resolver that pages through the list that resolve returns, by the first, after, last and
+// before arguments.  The cursors of the edges encode their offsets in the list.
+func resolveConnection(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if nil == resolve {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		result, err = resolve(p)
+		if nil != err {
+			return
+		}
+		list := reflect.ValueOf(result)
+		for list.IsValid() && reflect.Ptr == list.Kind() && !list.IsNil() {
+			list = list.Elem()
+		}
+		if !list.IsValid() || reflect.Ptr == list.Kind() || (reflect.Slice == list.Kind() && list.IsNil()) {
+			return nil, nil
+		}
+		if reflect.Slice != list.Kind() && reflect.Array != list.Kind() {
+			return nil, fmt.Errorf("got %T; expected a list", result)
+		}
+		start, end, err := pageBounds(list.Len(), p.Args)
+		if nil != err {
+			return nil, err
+		}
+		edges := make([]edge, 0, end-start)
+		for i := start; i < end; i++ {
+			edges = append(edges, edge{node: list.Index(i).Interface(), cursor: encodeOffsetCursor(i)})
+		}
+		length := list.Len()
+		info := pageInfo{hasPreviousPage: 0 < start, hasNextPage: end < length}
+		return newConnection(edges, info, func() (int, error) { return length, nil }), nil
+	}
+}
@@ -0,0 +1,116 @@
+package gographql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Book struct {
+	Title string
+}
+
+type Shelf struct {
+	Books []Book `connection:"true"`
+}
+
+// shelfSchema returns a schema whose query is a shelf of the books a to e.
+func shelfSchema(t *testing.T, tm typeMapper) graphql.Schema {
+	shelf := Shelf{}
+	for _, title := range []string{"a", "b", "c", "d", "e"} {
+		shelf.Books = append(shelf.Books, Book{Title: title})
+	}
+	schema, err := tm.NewSchemaBuilder().Query(shelf).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestOffsetConnection(t *testing.T) {
+	schema := shelfSchema(t, NewTypeMapper())
+	cursor := encodeOffsetCursor
+	for _, test := range []struct {
+		args     string
+		expected string
+	}{
+		{`first: 2`, `a b; next`},
+		{fmt.Sprintf(`first: 2, after: "%v"`, cursor(1)), `c d; previous next`},
+		{`last: 2`, `d e; previous`},
+		{fmt.Sprintf(`last: 2, before: "%v"`, cursor(3)), `b c; previous next`},
+		{fmt.Sprintf(`after: "%v", before: "%v"`, cursor(0), cursor(4)), `b c d; previous next`},
+		{`first: 3, last: 2`, `b c; previous next`},
+		{fmt.Sprintf(`first: 1, last: 3, after: "%v"`, cursor(2)), `d; previous next`},
+		{`first: 0`, `; next`},
+		{`first: 9`, `a b c d e;`},
+		{fmt.Sprintf(`after: "%v"`, cursor(10)), `; previous`},
+		{fmt.Sprintf(`before: "%v"`, cursor(0)), `; next`},
+		{fmt.Sprintf(`before: "%v"`, cursor(10)), `a b c d e;`},
+		{fmt.Sprintf(`after: "%v", before: "%v"`, cursor(3), cursor(1)), `; previous next`},
+	} {
+		request := fmt.Sprintf(`{Books(%v) {totalCount edges {cursor node {Title}} pageInfo {hasNextPage hasPreviousPage startCursor endCursor}}}`, test.args)
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: request})
+		if result.HasErrors() {
+			t.Fatalf("%v: %v", test.args, result.Errors)
+		}
+		books := result.Data.(map[string]interface{})["Books"].(map[string]interface{})
+		titles := []string{}
+		for _, e := range books["edges"].([]interface{}) {
+			e := e.(map[string]interface{})
+			title := e["node"].(map[string]interface{})["Title"].(string)
+			if offset, err := decodeOffsetCursor(e["cursor"].(string)); nil != err || title != string(rune('a'+offset)) {
+				t.Errorf("%v: the cursor of %v is %v, %v", test.args, title, offset, err)
+			}
+			titles = append(titles, title)
+		}
+		page := strings.Join(titles, " ") + ";"
+		info := books["pageInfo"].(map[string]interface{})
+		if true == info["hasPreviousPage"] {
+			page += " previous"
+		}
+		if true == info["hasNextPage"] {
+			page += " next"
+		}
+		if test.expected != page {
+			t.Errorf("%v: got %q; expected %q", test.args, page, test.expected)
+		}
+		if 5 != books["totalCount"] {
+			t.Errorf("%v: got the total count %v; expected 5", test.args, books["totalCount"])
+		}
+		if 0 == len(titles) && (nil != info["startCursor"] || nil != info["endCursor"]) {
+			t.Errorf("%v: got the cursors %v and %v of no edges; expected null", test.args, info["startCursor"], info["endCursor"])
+		}
+	}
+	for args, expected := range map[string]string{
+		`first: -1`:   "first cannot be negative",
+		`last: -1`:    "last cannot be negative",
+		`after: "!!"`: "after: \"!!\" is not a valid cursor",
+		fmt.Sprintf(`before: "%v"`, base64.StdEncoding.EncodeToString([]byte("offset:-1"))): "is not a valid cursor",
+		fmt.Sprintf(`after: "%v"`, base64.StdEncoding.EncodeToString([]byte("page:1"))):     "is not a valid cursor",
+		fmt.Sprintf(`after: "%v"`, base64.StdEncoding.EncodeToString([]byte("offset:x"))):   "is not a valid cursor",
+	} {
+		if message := executeError(t, schema, fmt.Sprintf(`{Books(%v) {totalCount}}`, args), nil); !strings.Contains(message, expected) {
+			t.Errorf("%v: got %q; expected %q", args, message, expected)
+		}
+	}
+}
+
+func TestPageInfoName(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.SetPageInfoName("ShelfPageInfo")
+	schema := shelfSchema(t, tm)
+	if nil != schema.Type("PageInfo") || nil == schema.Type("ShelfPageInfo") {
+		t.Errorf("got %v and %v; expected the page information to be named ShelfPageInfo", schema.Type("PageInfo"), schema.Type("ShelfPageInfo"))
+	}
+	if data, expected := execute(t, schema, `{Books(first: 1) {pageInfo {hasNextPage}}}`, nil), `{"Books":{"pageInfo":{"hasNextPage":true}}}`; expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+	tm = NewTypeMapper()
+	tm.SetPageInfoName("Book")
+	if _, err := tm.NewSchemaBuilder().Query(Shelf{}).Build(); nil == err || !strings.Contains(err.Error(), "SetPageInfoName") {
+		t.Errorf("got %v; expected an error that the name Book is taken", err)
+	}
+}
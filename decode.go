@@ -0,0 +1,239 @@
+package gographql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// DecodeArgs fills the struct that target points to from the arguments given to a resolver,
+// or from the value of an input object.
+// Values are decoded by the same rules that were used to translate the struct to an input type.
+func DecodeArgs(args map[string]interface{}, target interface{}) (err error) {
+	return objectMapper.DecodeArgs(args, target)
+}
+
+// DecodeArgs fills the struct that target points to from the arguments given to a resolver,
+// or from the value of an input object.
+// Values are decoded by the same rules that were used to translate the struct to an input type.
+// The errors that are returned name the path to the field that could not be decoded.
+func (tm *typeMapper) DecodeArgs(args map[string]interface{}, target interface{}) (err error) {
+	targetValue := reflect.ValueOf(target)
+	if reflect.Ptr != targetValue.Kind() || targetValue.IsNil() {
+		err = errors.New("the target must be a non-nil pointer to a struct")
+		return
+	}
+	if reflect.Struct != targetValue.Elem().Kind() {
+		err = fmt.Errorf("the target must point to a struct; it points to %v", targetValue.Elem().Type())
+		return
+	}
+	return tm.decodeStruct("", args, targetValue.Elem())
+}
+
+func (tm *typeMapper) decodeStruct(path string, values map[string]interface{}, target reflect.Value) (err error) {
+	for _, structField := range flattenedFields(target.Type()) {
+		if "" != structField.PkgPath {
+			continue
+		}
+		fieldName, skip := tm.fieldName(structField)
+		if skip {
+			continue
+		}
+		value, ok := values[fieldName]
+		if !ok {
+			continue
+		}
+		fieldPath := joinPath(path, fieldName)
+		field, err := fieldByIndexForSet(target, structField.Index)
+		if nil != err {
+			return fmt.Errorf("%v: %v", fieldPath, err)
+		}
+		substitute := tm.typeReplacer.GetType(structField.Tag.Get(ReplaceTypeWith))
+		var substituteType reflect.Type
+		if nil != substitute {
+			substituteType = *substitute
+		}
+		if err = tm.decodeValue(fieldPath, value, field, substituteType); nil != err {
+			return err
+		}
+	}
+	return
+}
+
+// decodeValue sets target from value.
+// substitute, when not nil, is the type that was translated in place of the type of target, or of its elements.
+func (tm *typeMapper) decodeValue(path string, value interface{}, target reflect.Value, substitute reflect.Type) (err error) {
+	if nil == value {
+		target.Set(reflect.Zero(target.Type()))
+		return
+	}
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+		return
+	}
+	if reflect.Ptr == source.Kind() && !source.IsNil() && source.Type().Elem().AssignableTo(target.Type()) {
+		target.Set(source.Elem())
+		return
+	}
+	if reflect.TypeOf(json.RawMessage{}) == target.Type() {
+		raw, err := json.Marshal(value)
+		if nil != err {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+		target.SetBytes(raw)
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(target.Type().Elem())
+		if err = tm.decodeValue(path, value, elem.Elem(), substitute); nil != err {
+			return
+		}
+		target.Set(elem)
+		return
+	case reflect.Slice, reflect.Array:
+		return tm.decodeList(path, value, target, substitute)
+	case reflect.Map:
+		return tm.decodeMap(path, value, target, substitute)
+	}
+	if nil != substitute && substitute != target.Type() {
+		substituted := reflect.New(substitute)
+		if err = tm.decodeValue(path, value, substituted.Elem(), nil); nil != err {
+			return
+		}
+		switch {
+		case substitute.AssignableTo(target.Type()):
+			target.Set(substituted.Elem())
+		case substituted.Type().AssignableTo(target.Type()):
+			target.Set(substituted)
+		case substitute.ConvertibleTo(target.Type()):
+			target.Set(substituted.Elem().Convert(target.Type()))
+		default:
+			err = fmt.Errorf("%v: cannot use substituted type %v as %v", path, substitute, target.Type())
+		}
+		return
+	}
+	switch target.Kind() {
+	case reflect.Struct:
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			err = fmt.Errorf("%v: cannot decode %T into %v", path, value, target.Type())
+			return
+		}
+		return tm.decodeStruct(path, values, target)
+	case reflect.Interface:
+		err = fmt.Errorf("%v: %T does not implement %v", path, value, target.Type())
+		return
+	}
+	return decodeScalar(path, source, target)
+}
+
+func (tm *typeMapper) decodeList(path string, value interface{}, target reflect.Value, substitute reflect.Type) (err error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		list = []interface{}{value}
+	}
+	elements := target
+	switch target.Kind() {
+	case reflect.Slice:
+		elements = reflect.MakeSlice(target.Type(), len(list), len(list))
+	case reflect.Array:
+		if len(list) > target.Len() {
+			err = fmt.Errorf("%v: got %v values; %v holds %v", path, len(list), target.Type(), target.Len())
+			return
+		}
+		elements = reflect.New(target.Type()).Elem()
+	}
+	for i, element := range list {
+		if err = tm.decodeValue(fmt.Sprintf("%v[%v]", path, i), element, elements.Index(i), substitute); nil != err {
+			return
+		}
+	}
+	target.Set(elements)
+	return
+}
+
+// decodeScalar sets target from source, converting between numeric kinds when the value fits in target.
+func decodeScalar(path string, source, target reflect.Value) (err error) {
+	mismatch := fmt.Errorf("%v: cannot decode %v into %v", path, source.Type(), target.Type())
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = source.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if source.Uint() > math.MaxInt64 {
+				return fmt.Errorf("%v: %v overflows %v", path, source.Uint(), target.Type())
+			}
+			i = int64(source.Uint())
+		case reflect.Float32, reflect.Float64:
+			f := source.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("%v: %v is not a %v", path, f, target.Type())
+			}
+			i = int64(f)
+		default:
+			return mismatch
+		}
+		if target.OverflowInt(i) {
+			return fmt.Errorf("%v: %v overflows %v", path, i, target.Type())
+		}
+		target.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if source.Int() < 0 {
+				return fmt.Errorf("%v: %v overflows %v", path, source.Int(), target.Type())
+			}
+			u = uint64(source.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			u = source.Uint()
+		case reflect.Float32, reflect.Float64:
+			f := source.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				return fmt.Errorf("%v: %v is not a %v", path, f, target.Type())
+			}
+			u = uint64(f)
+		default:
+			return mismatch
+		}
+		if target.OverflowUint(u) {
+			return fmt.Errorf("%v: %v overflows %v", path, u, target.Type())
+		}
+		target.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(source.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			f = float64(source.Uint())
+		case reflect.Float32, reflect.Float64:
+			f = source.Float()
+		default:
+			return mismatch
+		}
+		if target.OverflowFloat(f) {
+			return fmt.Errorf("%v: %v overflows %v", path, f, target.Type())
+		}
+		target.SetFloat(f)
+	default:
+		if !source.Type().ConvertibleTo(target.Type()) || source.Kind() != target.Kind() {
+			return mismatch
+		}
+		target.Set(source.Convert(target.Type()))
+	}
+	return
+}
+
+func joinPath(path, name string) string {
+	if "" == path {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,94 @@
+package gographql
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Host struct {
+	Name  string
+	Cores uint8
+}
+
+type Fleet struct {
+	Small    int8
+	Count    uint
+	Ratio    float32
+	Primary  *Host
+	Hosts    []Host
+	Labels   map[string]int
+	Weights  [2]int
+	Optional *int
+}
+
+type FleetArgs struct {
+	Filter Fleet
+}
+
+func TestDecodeArgs(t *testing.T) {
+	seven := 7
+	var args FleetArgs
+	err := DecodeArgs(map[string]interface{}{"Filter": map[string]interface{}{
+		"Small":    -128,
+		"Count":    3.0,
+		"Ratio":    2,
+		"Primary":  map[string]interface{}{"Name": "a", "Cores": uint64(4)},
+		"Hosts":    []interface{}{map[string]interface{}{"Name": "b"}, map[string]interface{}{"Cores": 255}},
+		"Labels":   []interface{}{map[string]interface{}{"key": "x", "value": 1}},
+		"Weights":  []interface{}{1},
+		"Optional": 7,
+	}}, &args)
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := FleetArgs{Filter: Fleet{
+		Small:    -128,
+		Count:    3,
+		Ratio:    2,
+		Primary:  &Host{Name: "a", Cores: 4},
+		Hosts:    []Host{{Name: "b"}, {Cores: 255}},
+		Labels:   map[string]int{"x": 1},
+		Weights:  [2]int{1, 0},
+		Optional: &seven,
+	}}
+	if !reflect.DeepEqual(expected, args) {
+		t.Errorf("got %+v; expected %+v", args, expected)
+	}
+	if err = DecodeArgs(map[string]interface{}{"Filter": map[string]interface{}{"Labels": map[string]interface{}{"y": 2}}}, &args); nil != err {
+		t.Fatal(err)
+	} else if expected := map[string]int{"y": 2}; !reflect.DeepEqual(expected, args.Filter.Labels) {
+		t.Errorf("got %v; expected %v", args.Filter.Labels, expected)
+	}
+}
+
+func TestDecodeArgsErrors(t *testing.T) {
+	for _, test := range []struct {
+		filter   map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"Small": 128}, "Filter.Small: 128 overflows int8"},
+		{map[string]interface{}{"Small": -129}, "Filter.Small: -129 overflows int8"},
+		{map[string]interface{}{"Small": 1.5}, "Filter.Small: 1.5 is not a int8"},
+		{map[string]interface{}{"Count": -1}, "Filter.Count: -1 overflows uint"},
+		{map[string]interface{}{"Count": -1.0}, "Filter.Count: -1 is not a uint"},
+		{map[string]interface{}{"Count": "3"}, "Filter.Count: cannot decode string into uint"},
+		{map[string]interface{}{"Primary": map[string]interface{}{"Cores": 256}}, "Filter.Primary.Cores: 256 overflows uint8"},
+		{map[string]interface{}{"Hosts": []interface{}{map[string]interface{}{}, map[string]interface{}{}, map[string]interface{}{"Name": 5}}}, "Filter.Hosts[2].Name: cannot decode int into string"},
+		{map[string]interface{}{"Hosts": []interface{}{"b"}}, "Filter.Hosts[0]: cannot decode string into gographql.Host"},
+		{map[string]interface{}{"Labels": []interface{}{map[string]interface{}{"key": "x", "value": "one"}}}, "Filter.Labels[0].value: cannot decode string into int"},
+		{map[string]interface{}{"Labels": map[string]interface{}{"x": -1.5}}, "Filter.Labels.x.value: -1.5 is not a int"},
+		{map[string]interface{}{"Labels": 1}, "Filter.Labels: cannot decode int into map[string]int"},
+		{map[string]interface{}{"Weights": []interface{}{1, 2, 3}}, "Filter.Weights: got 3 values; [2]int holds 2"},
+		{map[string]interface{}{"Optional": "x"}, "Filter.Optional: cannot decode string into int"},
+	} {
+		var args FleetArgs
+		if err := DecodeArgs(map[string]interface{}{"Filter": test.filter}, &args); nil == err || test.expected != err.Error() {
+			t.Errorf("%v: got %v; expected %v", test.filter, err, test.expected)
+		}
+	}
+	for _, target := range []interface{}{nil, FleetArgs{}, (*FleetArgs)(nil), new(int)} {
+		if err := DecodeArgs(map[string]interface{}{}, target); nil == err {
+			t.Errorf("%T: expected an error", target)
+		}
+	}
+}
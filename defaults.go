@@ -0,0 +1,123 @@
+package gographql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DefaultTag is the name of the key for a field tag key/value pair where the value is the default value of an input field.
+// The value is parsed as a literal of the field's graphql type.  Strings may be quoted or not, enum values are named,
+// and lists are written as "[a,b]"; a value that is not a list is a list of one.
+var DefaultTag = "default"
+
+// A defaultError is an invalid default tag.  It fails the translation of the input that has the field, and of the inputs
+// that have that input, rather than excluding the field the way that other field errors do.
+type defaultError struct {
+	err error
+}
+
+func (de defaultError) Error() string {
+	return de.err.Error()
+}
+
+func (de defaultError) Unwrap() error {
+	return de.err
+}
+
+// parseDefault returns the value of the literal for the graphql Type, the way that the Type parses the literal in a query.
+func parseDefault(literal string, Type graphql.Type) (value interface{}, err error) {
+	literal = strings.TrimSpace(literal)
+	switch Type := Type.(type) {
+	case *graphql.NonNull:
+		return parseDefault(literal, Type.OfType)
+	case *graphql.List:
+		elements := []string{literal}
+		if strings.HasPrefix(literal, "[") && strings.HasSuffix(literal, "]") {
+			elements = splitList(literal[1 : len(literal)-1])
+		}
+		list := make([]interface{}, 0, len(elements))
+		for _, element := range elements {
+			if value, err = parseDefault(element, Type.OfType); nil != err {
+				return
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case *graphql.Enum:
+		value = Type.ParseLiteral(&ast.EnumValue{Kind: "EnumValue", Value: literal})
+	case *graphql.Scalar:
+		if JSON == Type {
+			if err = json.Unmarshal([]byte(literal), &value); nil != err {
+				err = fmt.Errorf("default %v is not a JSON value; %v", literal, err)
+			}
+			return
+		}
+		valueAST, err := scalarLiteral(literal, Type)
+		if nil != err {
+			return nil, err
+		}
+		value = Type.ParseLiteral(valueAST)
+	default:
+		err = fmt.Errorf("defaults of type %v are not supported", Type)
+		return
+	}
+	if nil == value {
+		err = fmt.Errorf("default %v is not a valid %v", literal, Type)
+	}
+	return
+}
+
+// scalarLiteral returns the literal as the AST value that the scalar parses.
+func scalarLiteral(literal string, scalar *graphql.Scalar) (valueAST ast.Value, err error) {
+	if unquoted, err := strconv.Unquote(literal); nil == err && strings.HasPrefix(literal, `"`) {
+		return &ast.StringValue{Kind: "StringValue", Value: unquoted}, nil
+	}
+	switch scalar {
+	case graphql.Int, Int64, Uint64:
+		valueAST = &ast.IntValue{Kind: "IntValue", Value: literal}
+	case graphql.Float:
+		if _, err = strconv.ParseFloat(literal, 64); nil != err {
+			err = fmt.Errorf("default %v is not a valid %v", literal, scalar)
+			return
+		}
+		valueAST = &ast.FloatValue{Kind: "FloatValue", Value: literal}
+	case graphql.Boolean:
+		var b bool
+		if b, err = strconv.ParseBool(literal); nil != err {
+			err = fmt.Errorf("default %v is not a valid %v", literal, scalar)
+			return
+		}
+		valueAST = &ast.BooleanValue{Kind: "BooleanValue", Value: b}
+	default:
+		valueAST = &ast.StringValue{Kind: "StringValue", Value: literal}
+	}
+	return
+}
+
+// splitList splits the elements of a list literal at the commas that are not in quotes.
+func splitList(literal string) (elements []string) {
+	if "" == strings.TrimSpace(literal) {
+		return
+	}
+	quoted := false
+	start := 0
+	for i := 0; i < len(literal); i++ {
+		switch literal[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				elements = append(elements, literal[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(elements, literal[start:])
+}
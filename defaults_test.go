@@ -0,0 +1,28 @@
+package gographql
+
+import (
+	"strings"
+	"testing"
+)
+
+type Limits struct {
+	Retries int `default:"three"`
+}
+
+type Policy struct {
+	Name   string
+	Limits Limits
+}
+
+type Tenant struct {
+	Policies []Policy
+}
+
+func TestNestedInvalidDefault(t *testing.T) {
+	for _, input := range []interface{}{Limits{}, Policy{}, Tenant{}} {
+		tm := NewTypeMapper()
+		if _, err := tm.GoToGraphqlInput(input); nil == err || !strings.Contains(err.Error(), `"Limits_Input.Retries"`) {
+			t.Errorf("%T: got %v; expected the default of Limits.Retries to be invalid", input, err)
+		}
+	}
+}
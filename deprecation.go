@@ -0,0 +1,71 @@
+package gographql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// DeprecatedTag is the name of the key for a field tag key/value pair where the value is the reason that the field is deprecated.
+// It applies to the fields of output types, and to the fields of structs that are registered with RegisterEnumStruct.
+var DeprecatedTag = "deprecated"
+
+// A Deprecation is a deprecated field of a graphql type, or a deprecated value of a graphql enum.
+type Deprecation struct {
+	TypeName string
+	Name     string
+	Reason   string
+}
+
+// String returns the deprecation as "TypeName.Name: Reason".
+func (d Deprecation) String() string {
+	return fmt.Sprintf("%v.%v: %v", d.TypeName, d.Name, d.Reason)
+}
+
+// DeprecationReport returns the deprecated fields and enum values of the schema, ordered by type name and then by name.
+func DeprecationReport(schema graphql.Schema) (deprecations []Deprecation) {
+	typeMap := schema.TypeMap()
+	for _, typeName := range sortedTypeNames(typeMap) {
+		var fields graphql.FieldDefinitionMap
+		switch Type := typeMap[typeName].(type) {
+		case *graphql.Object:
+			fields = Type.Fields()
+		case *graphql.Interface:
+			fields = Type.Fields()
+		case *graphql.Enum:
+			for _, value := range sortedEnumValues(Type) {
+				if "" != value.DeprecationReason {
+					deprecations = append(deprecations, Deprecation{TypeName: typeName, Name: value.Name, Reason: value.DeprecationReason})
+				}
+			}
+		}
+		for _, fieldName := range sortedFieldNames(fields) {
+			if reason := fields[fieldName].DeprecationReason; "" != reason {
+				deprecations = append(deprecations, Deprecation{TypeName: typeName, Name: fieldName, Reason: reason})
+			}
+		}
+	}
+	return
+}
+
+// sortedEnumValues returns the values of enum in order of their names; graphql-go builds Values from a map.
+func sortedEnumValues(enum *graphql.Enum) (values []*graphql.EnumValueDefinition) {
+	values = append(values, enum.Values()...)
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
+	return
+}
+
+// sortedTypeNames returns the names of the types of typeMap, in order, leaving out the introspection types.
+func sortedTypeNames(typeMap graphql.TypeMap) (names []string) {
+	for name := range typeMap {
+		if !strings.HasPrefix(name, "__") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
@@ -0,0 +1,201 @@
+package gographql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// EnumTag is the name of the key for a field tag key/value pair where the value is a comma separated list of
+// the values of a graphql enum.  It applies to fields having a string kind, or a list of them.
+var EnumTag = "enum"
+
+var reEnumValueName = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// EnumValue describes a value of a graphql enum.
+// Value is the Go value, of the Go type that is registered as an enum, that the name stands for.
+type EnumValue struct {
+	Name              string
+	Value             interface{}
+	Description       string
+	DeprecationReason string
+}
+
+// RegisterEnum causes fields of the Go type of the values to be translated to a graphql enum having the values.
+// The enum is named the same as the Go type.
+func RegisterEnum(values ...EnumValue) (err error) {
+	return objectMapper.RegisterEnum(values...)
+}
+
+// RegisterEnum causes fields of the Go type of the values to be translated to a graphql enum having the values.
+// The enum is named the same as the Go type.
+func (tm *typeMapper) RegisterEnum(values ...EnumValue) (err error) {
+	if 0 == len(values) {
+		err = errors.New("an enum must have at least one value")
+		log.Error(err)
+		return
+	}
+	goType := reflect.TypeOf(values[0].Value)
+	if nil == goType || "" == goType.Name() {
+		err = fmt.Errorf("the values of an enum must have a named type; got %T", values[0].Value)
+		log.Error(err)
+		return
+	}
+	enum, err := newEnum(goType.Name(), goType, values)
+	if nil != err {
+		log.Error(err)
+		return
+	}
+	tm.enums[goType] = enum
+	return
+}
+
+// RegisterEnumValues causes fields of the Go type of the values to be translated to a graphql enum having the values.
+// The name of each value is the result of its String method when it is a fmt.Stringer, otherwise the value itself when it is a string kind.
+func RegisterEnumValues(values ...interface{}) (err error) {
+	return objectMapper.RegisterEnumValues(values...)
+}
+
+// RegisterEnumValues causes fields of the Go type of the values to be translated to a graphql enum having the values.
+// The name of each value is the result of its String method when it is a fmt.Stringer, otherwise the value itself when it is a string kind.
+func (tm *typeMapper) RegisterEnumValues(values ...interface{}) (err error) {
+	enumValues := make([]EnumValue, 0, len(values))
+	for _, value := range values {
+		var name string
+		switch v := value.(type) {
+		case fmt.Stringer:
+			name = v.String()
+		default:
+			if nil == value || reflect.String != reflect.TypeOf(value).Kind() {
+				err = fmt.Errorf("cannot name enum value %v; it is not a fmt.Stringer or a string", value)
+				log.Error(err)
+				return
+			}
+			name = reflect.ValueOf(value).String()
+		}
+		enumValues = append(enumValues, EnumValue{Name: name, Value: value})
+	}
+	return tm.RegisterEnum(enumValues...)
+}
+
+func newEnum(name string, goType reflect.Type, values []EnumValue) (enum *graphql.Enum, err error) {
+	valueConfigs := graphql.EnumValueConfigMap{}
+	for _, value := range values {
+		if reflect.TypeOf(value.Value) != goType {
+			err = fmt.Errorf(`enum "%v" value "%v" has type %T; expected type %v`, name, value.Name, value.Value, goType)
+			return
+		}
+		if !reEnumValueName.MatchString(value.Name) || "true" == value.Name || "false" == value.Name || "null" == value.Name {
+			err = fmt.Errorf(`enum "%v" value "%v" is not a valid graphql name`, name, value.Name)
+			return
+		}
+		if _, exists := valueConfigs[value.Name]; exists {
+			err = fmt.Errorf(`enum "%v" value "%v" is declared more than once`, name, value.Name)
+			return
+		}
+		valueConfigs[value.Name] = &graphql.EnumValueConfig{
+			Value:             value.Value,
+			Description:       value.Description,
+			DeprecationReason: value.DeprecationReason,
+		}
+	}
+	enum = graphql.NewEnum(graphql.EnumConfig{Name: name, Values: valueConfigs})
+	return
+}
+
+// goTypeToGraphqlEnum returns the enum for Type; either the registered one, or one from the field's enum tag.
+// Returns nil when Type is not an enum.
+func (tm *typeMapper) goTypeToGraphqlEnum(Type reflect.Type, structField reflect.StructField, structName string) (enum *graphql.Enum, err error) {
+	if enum, ok := tm.enums[Type]; ok {
+		return enum, nil
+	}
+	tagValue := structField.Tag.Get(EnumTag)
+	if "" == tagValue {
+		return
+	}
+	if reflect.String != Type.Kind() {
+		err = fmt.Errorf(`the %v tag applies to string kinds; the field is kind %v`, EnumTag, Type.Kind())
+		return
+	}
+	name := Type.Name()
+	if "" == name || Type.PkgPath() == "" {
+		// the input and output types of a struct share the enum
+		if graphqlInput == tm.targetType {
+			structName = strings.TrimSuffix(structName, "_Input")
+		}
+		name = structName + structField.Name
+	}
+	values := []EnumValue{}
+	valueNames := []string{}
+	for _, valueName := range strings.Split(tagValue, ",") {
+		valueName = strings.TrimSpace(valueName)
+		values = append(values, EnumValue{Name: valueName, Value: reflect.ValueOf(valueName).Convert(Type).Interface()})
+		valueNames = append(valueNames, valueName)
+	}
+	if enum, ok := tm.graphqlTypes[name].(*graphql.Enum); ok {
+		declaredNames := []string{}
+		for _, value := range enum.Values() {
+			declaredNames = append(declaredNames, value.Name)
+		}
+		sort.Strings(valueNames)
+		sort.Strings(declaredNames)
+		if strings.Join(valueNames, ",") != strings.Join(declaredNames, ",") {
+			err = fmt.Errorf(`enum "%v" is declared with the values %v by another field; this field declares %v`,
+				name, strings.Join(declaredNames, ","), strings.Join(valueNames, ","))
+			return nil, err
+		}
+		return enum, nil
+	}
+	if enum, err = newEnum(name, Type, values); nil != err {
+		return
+	}
+	tm.graphqlTypes[name] = enum
+	return
+}
+
+// RegisterEnumStruct causes fields of the Go type of the fields of valuesStruct to be translated to a graphql enum.
+// Each field of valuesStruct is a value of the enum.  It is named by the field's enum tag, or the field name when it has none,
+// and is described and deprecated by the field's description and deprecated tags.
+// All of the fields must have the same type; the enum is named the same as that type.
+func RegisterEnumStruct(valuesStruct interface{}) (err error) {
+	return objectMapper.RegisterEnumStruct(valuesStruct)
+}
+
+// RegisterEnumStruct causes fields of the Go type of the fields of valuesStruct to be translated to a graphql enum.
+// Each field of valuesStruct is a value of the enum.  It is named by the field's enum tag, or the field name when it has none,
+// and is described and deprecated by the field's description and deprecated tags.
+// All of the fields must have the same type; the enum is named the same as that type.
+func (tm *typeMapper) RegisterEnumStruct(valuesStruct interface{}) (err error) {
+	structValue := reflect.ValueOf(valuesStruct)
+	if reflect.Ptr == structValue.Kind() {
+		structValue = structValue.Elem()
+	}
+	if reflect.Struct != structValue.Kind() {
+		err = fmt.Errorf("the enum values must be the fields of a struct; got %T", valuesStruct)
+		log.Error(err)
+		return
+	}
+	values := []EnumValue{}
+	for fieldNumber := 0; fieldNumber < structValue.NumField(); fieldNumber++ {
+		structField := structValue.Type().Field(fieldNumber)
+		if "" != structField.PkgPath {
+			continue
+		}
+		name := tagName(structField.Tag.Get(EnumTag))
+		if "" == name {
+			name = structField.Name
+		}
+		values = append(values, EnumValue{
+			Name:              name,
+			Value:             structValue.Field(fieldNumber).Interface(),
+			Description:       structField.Tag.Get("description"),
+			DeprecationReason: structField.Tag.Get(DeprecatedTag),
+		})
+	}
+	return tm.RegisterEnum(values...)
+}
@@ -0,0 +1,65 @@
+package gographql
+
+import (
+	"strings"
+	"testing"
+)
+
+type Color string
+
+type Palette struct {
+	Primary   Color `enum:"RED, GREEN,BLUE"`
+	Secondary Color `enum:"BLUE,RED,GREEN"`
+}
+
+type Clash struct {
+	Primary Color `enum:"RED,GREEN,BLUE"`
+	Accent  Color `enum:"CYAN,MAGENTA"`
+}
+
+func TestEnumTags(t *testing.T) {
+	tm := NewTypeMapper()
+	object, err := tm.GoToGraphqlOutput(Palette{})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if primary, secondary := object.Fields()["Primary"].Type, object.Fields()["Secondary"].Type; primary != secondary {
+		t.Errorf("expected the fields to share an enum; got %v and %v", primary, secondary)
+	}
+	tm = NewTypeMapper()
+	if object, err = tm.GoToGraphqlOutput(Clash{}); nil != err {
+		t.Fatal(err)
+	}
+	if _, exists := object.Fields()["Accent"]; exists {
+		t.Error("expected Accent to be excluded")
+	}
+	excluded := tm.ExcludedFields()
+	if 1 != len(excluded) || "Accent" != excluded[0].FieldName || !strings.Contains(excluded[0].Reason, `enum "Color"`) {
+		t.Errorf("got %v; expected Accent to be excluded for the values of enum Color", excluded)
+	}
+}
+
+type Search struct {
+	Mode  string   `enum:"FAST,SLOW"`
+	Modes []string `enum:"FAST,SLOW"`
+}
+
+type Searcher struct{}
+
+func (Searcher) Find(args Search) Search { return args }
+
+func TestEnumTagSharedByInputAndOutput(t *testing.T) {
+	tm := NewTypeMapper()
+	schema, err := tm.NewSchemaBuilder().Query(Searcher{}).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, exists := schema.TypeMap()["Search_InputMode"]; exists {
+		t.Error("expected the input not to have an enum of its own")
+	}
+	request := `query($modes: [SearchModes]) {Find(Mode: SLOW, Modes: $modes) {Mode Modes}}`
+	variables := map[string]interface{}{"modes": []interface{}{"FAST", "SLOW"}}
+	if data, expected := execute(t, schema, request, variables), `{"Find":{"Mode":"SLOW","Modes":["FAST","SLOW"]}}`; expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+}
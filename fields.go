@@ -0,0 +1,170 @@
+package gographql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// NestedTag is the name of the key for a field tag key/value pair where the value is "true" or "false".
+// Fields of embedded structs are promoted to the embedding struct, unless the value is "true", in which
+// case the embedded struct is translated as a field named the same as its type.
+// An embedded struct that is named by a graphql tag is also translated as a field.
+var NestedTag = "nested"
+
+// flattenedFields returns the fields of structure, with the fields of embedded structs promoted the way that
+// encoding/json promotes them.  The Index of each field is its index sequence for reflect.Value.FieldByIndex.
+// A field that is shadowed by a field at a shallower depth is left out, as are fields that are ambiguous at their depth.
+// A struct that is embedded more than once at a depth is traversed once, and its fields are ambiguous at the next depth.
+func flattenedFields(structure reflect.Type) (fields []reflect.StructField) {
+	type embedded struct {
+		structure reflect.Type
+		index     []int
+		count     int
+	}
+	next := []*embedded{{structure: structure, count: 1}}
+	visited := map[reflect.Type]bool{}
+	shadowed := map[string]bool{}
+	for 0 != len(next) {
+		current := next
+		next = nil
+		nextByType := map[reflect.Type]*embedded{}
+		atDepth := []reflect.StructField{}
+		namesAtDepth := map[string]int{}
+		for _, e := range current {
+			if visited[e.structure] {
+				continue
+			}
+			visited[e.structure] = true
+			for fieldNumber := 0; fieldNumber < e.structure.NumField(); fieldNumber++ {
+				structField := e.structure.Field(fieldNumber)
+				graphqlName := tagName(structField.Tag.Get(GraphqlTag))
+				if "-" == graphqlName {
+					continue
+				}
+				structField.Index = append(append([]int{}, e.index...), fieldNumber)
+				namesAtDepth[structField.Name] += e.count
+				fieldType := structField.Type
+				if reflect.Ptr == fieldType.Kind() {
+					fieldType = fieldType.Elem()
+				}
+				if structField.Anonymous && reflect.Struct == fieldType.Kind() && "" == graphqlName && "true" != structField.Tag.Get(NestedTag) {
+					if shadowed[structField.Name] {
+						continue
+					}
+					if e, ok := nextByType[fieldType]; ok {
+						e.count++
+						continue
+					}
+					nextByType[fieldType] = &embedded{structure: fieldType, index: structField.Index, count: 1}
+					next = append(next, nextByType[fieldType])
+					continue
+				}
+				atDepth = append(atDepth, structField)
+			}
+		}
+		for _, structField := range atDepth {
+			if shadowed[structField.Name] || 1 < namesAtDepth[structField.Name] {
+				continue
+			}
+			fields = append(fields, structField)
+		}
+		for name := range namesAtDepth {
+			shadowed[name] = true
+		}
+	}
+	return
+}
+
+// fieldNameTaken reports whether fields, a graphql.Fields or a graphql.InputObjectConfigFieldMap, has a field named name.
+func fieldNameTaken(fields interface{}, name string) (taken bool) {
+	switch fields := fields.(type) {
+	case graphql.Fields:
+		_, taken = fields[name]
+	case graphql.InputObjectConfigFieldMap:
+		_, taken = fields[name]
+	}
+	return
+}
+
+// resolveFieldByIndex returns a resolver that reads the field at index of a source of type structure.
+// Sources of other types are resolved by graphql.DefaultResolveFn.
+func resolveFieldByIndex(structure reflect.Type, index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		source := reflect.ValueOf(p.Source)
+		if source.IsValid() && reflect.Ptr == source.Kind() {
+			if source.IsNil() {
+				return nil, nil
+			}
+			source = source.Elem()
+		}
+		if !source.IsValid() || source.Type() != structure {
+			return graphql.DefaultResolveFn(p)
+		}
+		for _, fieldNumber := range index {
+			if reflect.Ptr == source.Kind() {
+				if source.IsNil() {
+					return nil, nil
+				}
+				source = source.Elem()
+			}
+			source = source.Field(fieldNumber)
+		}
+		return source.Interface(), nil
+	}
+}
+
+// fieldByIndexForSet returns the field of structure at index, allocating the embedded structs that are nil pointers.
+func fieldByIndexForSet(structure reflect.Value, index []int) (field reflect.Value, err error) {
+	field = structure
+	for i, fieldNumber := range index {
+		if 0 < i && reflect.Ptr == field.Kind() {
+			if field.IsNil() {
+				if !field.CanSet() {
+					err = fmt.Errorf("cannot set embedded pointer to unexported struct %v", field.Type().Elem())
+					return
+				}
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			field = field.Elem()
+		}
+		field = field.Field(fieldNumber)
+	}
+	return
+}
+
+// An ExcludedField is a struct field that was left out of a graphql type, with the reason it was left out.
+type ExcludedField struct {
+	TypeName  string
+	FieldName string
+	Reason    string
+}
+
+// String returns the field as "TypeName.FieldName: Reason".
+func (ef ExcludedField) String() string {
+	return fmt.Sprintf("%v.%v: %v", ef.TypeName, ef.FieldName, ef.Reason)
+}
+
+// ExcludedFields returns the struct fields that were left out of the translated types, in the order they were found.
+func ExcludedFields() []ExcludedField {
+	return objectMapper.ExcludedFields()
+}
+
+// ExcludedFields returns the struct fields that were left out of the translated types, in the order they were found.
+func (tm *typeMapper) ExcludedFields() []ExcludedField {
+	return append([]ExcludedField{}, tm.excludedFields...)
+}
+
+// exclude records that the field of the graphql type named typeName was left out, and returns the record.
+func (tm *typeMapper) exclude(typeName, fieldName, reason string) (excluded ExcludedField) {
+	excluded = ExcludedField{TypeName: typeName, FieldName: fieldName, Reason: reason}
+	for _, recorded := range tm.excludedFields {
+		if recorded == excluded {
+			return
+		}
+	}
+	tm.excludedFields = append(tm.excludedFields, excluded)
+	log.Infof(`%vIgnoring "%v.%v"; %v`, tm.indent(), typeName, fieldName, reason)
+	return
+}
@@ -0,0 +1,86 @@
+package gographql
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type Stamp struct {
+	Version int
+}
+
+type Audit struct {
+	Created string
+	Stamp
+}
+
+type Left struct {
+	Audit
+	Side string
+}
+
+type Right struct {
+	Audit
+	Side string
+}
+
+type Record struct {
+	ID string
+	Left
+	Right
+}
+
+type Labeled struct {
+	Audit
+	Created string
+}
+
+func TestFlattenedFieldsFollowJSON(t *testing.T) {
+	for _, value := range []interface{}{Record{}, Labeled{}, Left{}} {
+		names := []string{}
+		for _, structField := range flattenedFields(reflect.TypeOf(value)) {
+			names = append(names, structField.Name)
+		}
+		sort.Strings(names)
+		data, err := json.Marshal(value)
+		if nil != err {
+			t.Fatal(err)
+		}
+		keys := map[string]interface{}{}
+		if err = json.Unmarshal(data, &keys); nil != err {
+			t.Fatal(err)
+		}
+		expected := []string{}
+		for key := range keys {
+			expected = append(expected, key)
+		}
+		sort.Strings(expected)
+		if !reflect.DeepEqual(expected, names) {
+			t.Errorf("%T: got %v; expected the fields that encoding/json encodes, %v", value, names, expected)
+		}
+	}
+}
+
+type Worker struct {
+	Name  string
+	Jobs  chan int
+	Run   func()
+	quota int
+}
+
+func TestExcludedFields(t *testing.T) {
+	tm := NewTypeMapper()
+	if _, err := tm.GoToGraphqlOutput(Worker{}); nil != err {
+		t.Fatal(err)
+	}
+	expected := []ExcludedField{
+		{TypeName: "Worker", FieldName: "Jobs", Reason: "fields of kind chan cannot be resolved"},
+		{TypeName: "Worker", FieldName: "Run", Reason: "fields of kind func cannot be resolved"},
+		{TypeName: "Worker", FieldName: "quota", Reason: "it is not exported"},
+	}
+	if excluded := tm.ExcludedFields(); !reflect.DeepEqual(expected, excluded) {
+		t.Errorf("got %v; expected %v", excluded, expected)
+	}
+}
@@ -0,0 +1,308 @@
+package gographql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// orderedScalars names the scalars that have the operators lt, lte, gt and gte in filters.
+var orderedScalars = map[string]bool{
+	"Int": true, "Float": true, "String": true, "ID": true, "Int64": true, "Uint64": true, "DateTime": true,
+	"ObjectID": true, "Decimal": true, "Duration": true, "BSONDateTime": true, "BSONTimestamp": true,
+}
+
+// textScalars names the scalars that have the operator regex in filters.
+var textScalars = map[string]bool{"String": true, "ID": true}
+
+// filterOperators are the operators of the fields of filters, in the order that they are translated to bson.
+var filterOperators = []struct {
+	name  string
+	bson  string
+	which string
+}{
+	{"eq", "$eq", "value"}, {"ne", "$ne", "value"},
+	{"gt", "$gt", "value"}, {"gte", "$gte", "value"}, {"lt", "$lt", "value"}, {"lte", "$lte", "value"},
+	{"in", "$in", "list"}, {"nin", "$nin", "list"},
+	{"exists", "$exists", "raw"}, {"regex", "$regex", "raw"},
+}
+
+// FilterInput returns the <Type>_Filter input object of the output type of the Go struct, given as a value,
+// a pointer to one, or its reflect.Type.  See FilterToBSON.
+func FilterInput(goStruct interface{}) (filter *graphql.InputObject, err error) {
+	return objectMapper.FilterInput(goStruct)
+}
+
+// FilterInput returns the <Type>_Filter input object of the output type of the Go struct, given as a value,
+// a pointer to one, or its reflect.Type.
+// The filter has a field for each field of the output type that is a scalar or an enum, having the operators
+// eq, ne, in, nin and exists; lt, lte, gt and gte for scalars that are ordered, such as numbers, strings and dates;
+// and regex for String and ID.  Fields of nested structs have the filter of the struct.  The fields and, or and not
+// combine filters.  See FilterToBSON.
+func (tm *typeMapper) FilterInput(goStruct interface{}) (filter *graphql.InputObject, err error) {
+	Type, err := structType(goStruct)
+	if nil != err {
+		return
+	}
+	object, err := tm.GoToGraphqlOutput(Type)
+	if nil != err {
+		return
+	}
+	return tm.filterInput(Type, object), nil
+}
+
+func (tm *typeMapper) filterInput(Type reflect.Type, object *graphql.Object) (filter *graphql.InputObject) {
+	name := object.Name() + "_Filter"
+	if filter, ok := tm.graphqlTypes[name].(*graphql.InputObject); ok {
+		return filter
+	}
+	filter = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        name,
+		Description: fmt.Sprintf("A filter of %v values.", object.Name()),
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			return tm.filterFields(Type, object, filter)
+		}),
+	})
+	tm.graphqlTypes[name] = filter
+	return
+}
+
+func (tm *typeMapper) filterFields(Type reflect.Type, object *graphql.Object, filter *graphql.InputObject) (fields graphql.InputObjectConfigFieldMap) {
+	fields = graphql.InputObjectConfigFieldMap{
+		"and": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(filter)),
+			Description: "Matches when every filter matches.",
+		},
+		"or": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(filter)),
+			Description: "Matches when any filter matches.",
+		},
+		"not": &graphql.InputObjectFieldConfig{
+			Type:        filter,
+			Description: "Matches when the filter does not match.",
+		},
+	}
+	objectFields := object.Fields()
+	for name, path := range tm.structPaths(Type) {
+		fieldDef, ok := objectFields[name]
+		if !ok {
+			continue
+		}
+		if _, exists := fields[name]; exists {
+			log.Infof(`%vLeaving field "%v" out of %v; it is named the same as a combinator`, tm.indent(), name, filter.Name())
+			continue
+		}
+		var fieldType graphql.Input
+		switch named := graphql.GetNamed(fieldDef.Type).(type) {
+		case *graphql.Scalar:
+			fieldType = tm.operatorsInput(named, orderedScalars[named.Name()], textScalars[named.Name()])
+		case *graphql.Enum:
+			fieldType = tm.operatorsInput(named, false, false)
+		case *graphql.Object:
+			if nested := tm.nestedStruct(path.Type); nil != nested {
+				fieldType = tm.filterInput(nested, named)
+			}
+		}
+		if nil == fieldType {
+			continue
+		}
+		fields[name] = &graphql.InputObjectFieldConfig{Type: fieldType}
+	}
+	return
+}
+
+// operatorsInput returns the <Type>_Filter input object of the operators of a scalar or enum.
+func (tm *typeMapper) operatorsInput(Type graphql.Input, ordered, text bool) (operators *graphql.InputObject) {
+	name := Type.Name() + "_Filter"
+	if operators, ok := tm.graphqlTypes[name].(*graphql.InputObject); ok {
+		return operators
+	}
+	fields := graphql.InputObjectConfigFieldMap{}
+	for _, operator := range filterOperators {
+		var operatorType graphql.Input
+		switch operator.name {
+		case "eq", "ne":
+			operatorType = Type
+		case "gt", "gte", "lt", "lte":
+			if ordered {
+				operatorType = Type
+			}
+		case "in", "nin":
+			operatorType = graphql.NewList(graphql.NewNonNull(Type))
+		case "exists":
+			operatorType = graphql.Boolean
+		case "regex":
+			if text {
+				operatorType = graphql.String
+			}
+		}
+		if nil != operatorType {
+			fields[operator.name] = &graphql.InputObjectFieldConfig{Type: operatorType}
+		}
+	}
+	operators = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        name,
+		Description: fmt.Sprintf("The operators that filter %v values.", Type.Name()),
+		Fields:      fields,
+	})
+	tm.graphqlTypes[name] = operators
+	return
+}
+
+// FilterToBSON translates the value of a <Type>_Filter argument, as it is given to a resolver, to a Mongo filter.
+// The names of fields are translated to bson paths using the bson tags of the Go struct, given as a value, a pointer
+// to one, or its reflect.Type, and values are converted to the Go types of the fields.
+func FilterToBSON(goStruct interface{}, filter interface{}) (document bson.D, err error) {
+	return objectMapper.FilterToBSON(goStruct, filter)
+}
+
+// FilterToBSON translates the value of a <Type>_Filter argument, as it is given to a resolver, to a Mongo filter.
+// The names of fields are translated to bson paths using the bson tags of the Go struct, given as a value, a pointer
+// to one, or its reflect.Type, and values are converted to the Go types of the fields.
+// For example {name: {eq: "a"}, addr: {city: {in: ["Oslo"]}}, not: {cpus: {lt: 2}}} is translated to
+// {"name": {"$eq": "a"}, "addr.city": {"$in": ["Oslo"]}, "$nor": [{"cpus": {"$lt": 2}}]}.
+func (tm *typeMapper) FilterToBSON(goStruct interface{}, filter interface{}) (document bson.D, err error) {
+	Type, err := structType(goStruct)
+	if nil != err {
+		return
+	}
+	return tm.filterToBSON("filter", Type, "", filter)
+}
+
+// filterToBSON translates the filter of the fields of Type, which are at bsonPrefix in documents.
+// path is the path of the filter in the argument, for errors.
+// The $and, $or and $nor of the filter, and of the filters of nested structs, follow the fields; since a document can
+// have a key only once, they are combined by $and when there are more than one.
+func (tm *typeMapper) filterToBSON(path string, Type reflect.Type, bsonPrefix string, filter interface{}) (document bson.D, err error) {
+	document = bson.D{}
+	var combinators []bson.E
+	if nil == filter {
+		return
+	}
+	object, ok := filter.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v: got %T; expected a filter", path, filter)
+	}
+	paths := tm.structPaths(Type)
+	for _, name := range sortedKeys(object) {
+		value := object[name]
+		if nil == value {
+			continue
+		}
+		fieldPath := joinPath(path, name)
+		switch name {
+		case "and", "or":
+			list, ok := value.([]interface{})
+			if !ok {
+				list = []interface{}{value}
+			}
+			filters := bson.A{}
+			for i, element := range list {
+				elementFilter, err := tm.filterToBSON(fmt.Sprintf("%v[%v]", fieldPath, i), Type, bsonPrefix, element)
+				if nil != err {
+					return nil, err
+				}
+				filters = append(filters, elementFilter)
+			}
+			if 0 != len(filters) {
+				combinators = append(combinators, bson.E{Key: "$" + name, Value: filters})
+			}
+			continue
+		case "not":
+			negated, err := tm.filterToBSON(fieldPath, Type, bsonPrefix, value)
+			if nil != err {
+				return nil, err
+			}
+			if 0 != len(negated) {
+				combinators = append(combinators, bson.E{Key: "$nor", Value: bson.A{negated}})
+			}
+			continue
+		}
+		field, ok := paths[name]
+		if !ok {
+			return nil, fmt.Errorf(`%v: there is no field named "%v"`, path, name)
+		}
+		bsonName := joinPath(bsonPrefix, field.bson)
+		if nested := tm.nestedStruct(field.Type); nil != nested {
+			nestedDocument, err := tm.filterToBSON(fieldPath, nested, bsonName, value)
+			if nil != err {
+				return nil, err
+			}
+			for _, element := range nestedDocument {
+				if strings.HasPrefix(element.Key, "$") {
+					combinators = append(combinators, element)
+				} else {
+					document = append(document, element)
+				}
+			}
+			continue
+		}
+		operators, err := tm.operatorsToBSON(fieldPath, value, field.Type)
+		if nil != err {
+			return nil, err
+		}
+		if 0 != len(operators) {
+			document = append(document, bson.E{Key: bsonName, Value: operators})
+		}
+	}
+	switch len(combinators) {
+	case 0:
+	case 1:
+		document = append(document, combinators[0])
+	default:
+		clauses := bson.A{}
+		for _, combinator := range combinators {
+			clauses = append(clauses, bson.D{combinator})
+		}
+		document = append(document, bson.E{Key: "$and", Value: clauses})
+	}
+	return
+}
+
+// operatorsToBSON translates the operators of a field of the Go Type to Mongo query operators.
+func (tm *typeMapper) operatorsToBSON(path string, value interface{}, Type reflect.Type) (document bson.D, err error) {
+	operators, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v: got %T; expected operators", path, value)
+	}
+	known := map[string]bool{}
+	for _, operator := range filterOperators {
+		known[operator.name] = true
+		operand, ok := operators[operator.name]
+		if !ok || nil == operand {
+			continue
+		}
+		operatorPath := joinPath(path, operator.name)
+		switch operator.which {
+		case "value":
+			operand, err = tm.coerceCondition(operatorPath, operand, Type)
+		case "list":
+			list, ok := operand.([]interface{})
+			if !ok {
+				list = []interface{}{operand}
+			}
+			values := bson.A{}
+			for i, element := range list {
+				coerced, err := tm.coerceCondition(fmt.Sprintf("%v[%v]", operatorPath, i), element, Type)
+				if nil != err {
+					return nil, err
+				}
+				values = append(values, coerced)
+			}
+			operand = values
+		}
+		if nil != err {
+			return
+		}
+		document = append(document, bson.E{Key: operator.bson, Value: operand})
+	}
+	for _, name := range sortedKeys(operators) {
+		if !known[name] {
+			return nil, fmt.Errorf(`%v: there is no operator named "%v"`, path, name)
+		}
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package gographql
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestFilterToBSON(t *testing.T) {
+	tm := NewTypeMapper()
+	document, err := tm.FilterToBSON(Server{}, map[string]interface{}{
+		"Name": map[string]interface{}{"in": []interface{}{"a", "b"}},
+		"Location": map[string]interface{}{
+			"City": map[string]interface{}{"ne": "Rome"},
+		},
+		"not": map[string]interface{}{"Cpus": map[string]interface{}{"lt": 2}},
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := bson.D{
+		{Key: "location.city", Value: bson.D{{Key: "$ne", Value: "Rome"}}},
+		{Key: "name", Value: bson.D{{Key: "$in", Value: bson.A{"a", "b"}}}},
+		{Key: "$nor", Value: bson.A{bson.D{{Key: "cpus", Value: bson.D{{Key: "$lt", Value: 2}}}}}},
+	}
+	if !reflect.DeepEqual(expected, document) {
+		t.Errorf("got %v; expected %v", document, expected)
+	}
+}
+
+func TestFilterToBSONNestedCombinators(t *testing.T) {
+	tm := NewTypeMapper()
+	document, err := tm.FilterToBSON(Server{}, map[string]interface{}{
+		"or": []interface{}{
+			map[string]interface{}{"Name": map[string]interface{}{"eq": "a"}},
+			map[string]interface{}{"Name": map[string]interface{}{"eq": "c"}},
+		},
+		"Location": map[string]interface{}{
+			"or": []interface{}{
+				map[string]interface{}{"City": map[string]interface{}{"eq": "Oslo"}},
+				map[string]interface{}{"City": map[string]interface{}{"eq": "Rome"}},
+			},
+		},
+	})
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "location.city", Value: bson.D{{Key: "$eq", Value: "Oslo"}}}},
+			bson.D{{Key: "location.city", Value: bson.D{{Key: "$eq", Value: "Rome"}}}},
+		}}},
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "name", Value: bson.D{{Key: "$eq", Value: "a"}}}},
+			bson.D{{Key: "name", Value: bson.D{{Key: "$eq", Value: "c"}}}},
+		}}},
+	}}}
+	if !reflect.DeepEqual(expected, document) {
+		t.Errorf("got %v; expected %v", document, expected)
+	}
+	_, schema := collectionSchema(t, servers(t)...)
+	request := `{servers(where: {or: [{Name: {eq: "a"}}, {Name: {eq: "d"}}], Location: {or: [{City: {eq: "Rome"}}, {City: {eq: "Lima"}}]}}) {Name}}`
+	if data, expected := execute(t, schema, request, nil), `{"servers":[{"Name":"d"}]}`; expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+}
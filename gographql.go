@@ -21,13 +21,27 @@ The value for the key named "replaceTypeWith" is a string that names a Go type t
 
 The value for the key named "description" is a string that will be assigned to the description attribute of the graphql type.
 
-The value for the key named "required" is "true" or "false".  It only works with "ptr" kinds and will cause the graphql field to be declared NONNULL.
+The value for the key named "required" is "true" or "false".  It works with all kinds; "true" will cause the graphql field to be declared NONNULL and "false" will cause it to be nullable.  Without it, fields that are not pointers, interfaces, slices or maps are declared NONNULL when SetNonNullByDefault is set.
+
+The value for the key named "requiredElements" is "true" or "false".  It is the same as "required" for the elements of a list, or the values of a map; for example "[Host!]".
+
+The value for the key named "default" is the default value of an input field.  It is parsed the way that a literal of the field's graphql type is parsed; enum values are named, and lists are written as "[a,b]".  An invalid default is a translation error of the input, and of every input that the input is nested in.
+
+The value for the key named "deprecated" is the reason that the field is deprecated.  It applies to fields of output types.
+
+The value for the key named "graphql" is the name of the graphql field, or "-" to leave the field out.  It overrides the FieldNamer.
+
+The value for the key named "nested" is "true" or "false".  The fields of an embedded (anonymous) struct are promoted to the embedding struct's type the way that encoding/json promotes them; a field shadows the fields of the same name that are embedded more deeply, and fields that are ambiguous at the same depth, including those of a struct that is embedded more than once at a depth, are left out.  "true" keeps the embedded struct as a field named the same as its type.
+
+The value for the key named "union" names a union that is registered with RegisterUnion.  It works with interface kinds, and lists of them, and will cause the graphql field to be declared as that union.
+
+The value for the key named "enum" is a comma separated list of the values of a graphql enum.  It works with string kinds, and lists of them, and will cause the graphql field to be declared as that enum.  Use RegisterEnum, or RegisterEnumValues, to declare enums of other kinds.
 
 Structs having no fields are not translated and so will have no equivalent field in the graphql type.
 
 Field resolver functions
 
-The resolver for fields of type interface produce/input a JSON document that is in the form of a string.
+Fields of type interface are translated to the JSON scalar, which produces/inputs JSON values; unless the interface has implementations registered with RegisterImplementations, in which case the field of an output type is a graphql interface.
 
 Most Go structures are composed of other structures and scalar types and so the resolution of how to input and output the data is "built-in".  For example, if a struct is composed of some ints and strings, the functions for reading and writing those datum are built into the language already.  Sometimes there is the case when the resolver for the output type needs to be custom.  To accomplish that, one may implement a FieldResolverFinder for gographql to use.  FieldResolverFinder has a method that takes the name of a field type as a string, and returns its resolver function, or nil if none was found.
 
@@ -125,6 +139,7 @@ Configure gographql for a TypeReplacer:
 package gographql
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -132,7 +147,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
@@ -166,8 +180,9 @@ func (tt targetType) String() (name string) {
 // Use a TypeReplacer to resolve the value to the actual type.
 var ReplaceTypeWith = "replaceTypeWith"
 var (
-	reStub       = regexp.MustCompile(`(.*)Stub`)
-	reList       = regexp.MustCompile(`\[(.*)Stub\]`)
+	reStub = regexp.MustCompile(`^(.*)Stub$`)
+	// RENonNull matched the names of NonNull stubs.
+	// Deprecated: stubs are replaced by unwrapping their List and NonNull types.
 	RENonNull    = regexp.MustCompile(`(.*)Stub\!`)
 	reReturnsPtr = regexp.MustCompile(`\(\) \*`)
 	objectMapper = NewTypeMapper()
@@ -183,12 +198,28 @@ func init() {
 }
 
 type typeMapper struct {
-	graphqlTypes        map[string]graphql.Type
-	parentTypes         map[string]bool
-	level               uint
-	typeReplacer        TypeReplacer
-	fieldResolverFinder FieldResolverFinder
-	targetType          targetType
+	graphqlTypes         map[string]graphql.Type
+	parentTypes          map[string]bool
+	level                uint
+	typeReplacer         TypeReplacer
+	fieldResolverFinder  FieldResolverFinder
+	targetType           targetType
+	ignoreMethods        bool
+	ignoredMethods       map[string]bool
+	enums                map[reflect.Type]*graphql.Enum
+	implementations      map[reflect.Type][]reflect.Type
+	interfaces           map[reflect.Type]*graphql.Interface
+	objectInterfaces     map[string][]*graphql.Interface
+	unions               map[string][]reflect.Type
+	fieldNamer           FieldNamer
+	excludedFields       []ExcludedField
+	nonNullByDefault     bool
+	scalars              map[reflect.Type]*graphql.Scalar
+	int64AsString        bool
+	collections          []*collectionBinding
+	connectionsByDefault bool
+	pageInfo             *graphql.Object
+	pageInfoUsed         bool
 }
 
 // NewTypeMapper creates a new type mapper.
@@ -198,8 +229,17 @@ func NewTypeMapper() (tm typeMapper) {
 	tm = typeMapper{
 		graphqlTypes:        map[string]graphql.Type{},
 		parentTypes:         map[string]bool{},
+		enums:               map[reflect.Type]*graphql.Enum{},
+		implementations:     map[reflect.Type][]reflect.Type{},
+		interfaces:          map[reflect.Type]*graphql.Interface{},
+		objectInterfaces:    map[string][]*graphql.Interface{},
+		unions:              map[string][]reflect.Type{},
+		ignoredMethods:      map[string]bool{},
 		typeReplacer:        defaultTypeReplacer{},
 		fieldResolverFinder: defaultFieldResolverFinder{},
+		fieldNamer:          defaultFieldNamer{},
+		scalars:             defaultScalars(),
+		pageInfo:            PageInfo,
 	}
 	return tm
 }
@@ -326,20 +366,29 @@ func (tm *typeMapper) GoToGraphqlInput(goStruct interface{}) (inputObject *graph
 	}
 	return
 }
-func getType(tm *typeMapper, typeName, kindName string) (fieldType graphql.Type, err error) {
-	fieldType, exists := tm.graphqlTypes[typeName]
-	if !exists {
-		err = fmt.Errorf(`%v %v object not found for typeName "%v"`, tm.indent(), tm.level, typeName)
-		log.Error(err)
-		return
+
+// getType returns fieldType with its stub replaced by the type that the stub stands for, keeping the List and NonNull types that wrap it.
+func getType(tm *typeMapper, fieldType graphql.Type) (replaced graphql.Type, err error) {
+	switch fieldType := fieldType.(type) {
+	case *graphql.List:
+		if replaced, err = getType(tm, fieldType.OfType); nil != err {
+			return
+		}
+		return graphql.NewList(replaced), nil
+	case *graphql.NonNull:
+		if replaced, err = getType(tm, fieldType.OfType); nil != err {
+			return
+		}
+		return graphql.NewNonNull(replaced), nil
 	}
-	if words := reList.FindStringSubmatch(kindName); nil != words {
+	typeName := fieldType.Name()
+	if words := reStub.FindStringSubmatch(typeName); nil != words {
 		typeName = words[1]
-		fieldType = graphql.NewList(fieldType)
 	}
-	if words := RENonNull.FindStringSubmatch(kindName); nil != words {
-		typeName = words[1]
-		fieldType = graphql.NewNonNull(fieldType)
+	replaced, exists := tm.graphqlTypes[typeName]
+	if !exists {
+		err = fmt.Errorf(`%v %v object not found for typeName "%v"`, tm.indent(), tm.level, typeName)
+		log.Error(err)
 	}
 	return
 }
@@ -423,30 +472,20 @@ func (tm *typeMapper) goToGraphqlType(goStruct interface{}) (graphqlType graphql
 				switch obj := Type.(type) {
 				case *graphql.Object:
 					for fieldKey, fieldDef := range obj.Fields() {
-						stubbedTypeName := fieldDef.Type.Name()
-						words := reStub.FindStringSubmatch(stubbedTypeName)
-						if nil == words {
+						stubbedTypeName := graphql.GetNamed(fieldDef.Type).String()
+						if !reStub.MatchString(stubbedTypeName) {
 							continue
 						}
 						delete(obj.Fields(), fieldKey)
-						typeName := words[1]
-						kindName := fmt.Sprintf("%v", reflect.ValueOf(fieldDef.Type))
-						fieldType, err := getType(tm, typeName, kindName)
+						fieldType, err := getType(tm, fieldDef.Type)
 						if nil != err {
 							log.Warn(err)
 							continue
 						}
-						obj.AddFieldConfig(
-							fieldKey,
-							&graphql.Field{
-								Name:              fieldKey,
-								Type:              fieldType,
-								Args:              graphql.FieldConfigArgument{},
-								Resolve:           fieldDef.Resolve,
-								DeprecationReason: fieldDef.DeprecationReason,
-								Description:       fieldDef.Description,
-							},
-						)
+						field := fieldFromDefinition(fieldDef)
+						field.Name = fieldKey
+						field.Type = fieldType
+						obj.AddFieldConfig(fieldKey, field)
 						log.Infof(
 							`%v %v Replaced %v.%v, of type %v with type %v.`,
 							tm.indent(), tm.level, obj.Name(), fieldKey, stubbedTypeName, fieldType.Name(),
@@ -454,15 +493,12 @@ func (tm *typeMapper) goToGraphqlType(goStruct interface{}) (graphqlType graphql
 					}
 				case *graphql.InputObject:
 					for fieldKey, fieldDef := range obj.Fields() {
-						stubbedTypeName := fieldDef.Type.Name()
-						words := reStub.FindStringSubmatch(stubbedTypeName)
-						if nil == words {
+						stubbedTypeName := graphql.GetNamed(fieldDef.Type).String()
+						if !reStub.MatchString(stubbedTypeName) {
 							continue
 						}
 						delete(obj.Fields(), fieldKey)
-						typeName := words[1]
-						kindName := fmt.Sprintf("%v", reflect.ValueOf(fieldDef.Type))
-						fieldType, err := getType(tm, typeName, kindName)
+						fieldType, err := getType(tm, fieldDef.Type)
 						if nil != err {
 							log.Warn(err)
 							continue
@@ -480,6 +516,18 @@ func (tm *typeMapper) goToGraphqlType(goStruct interface{}) (graphqlType graphql
 							tm.indent(), tm.level, obj.Name(), fieldKey, stubbedTypeName, fieldType.Name(),
 						)
 					}
+				case *graphql.Union:
+					members := obj.Types()
+					for i, member := range members {
+						words := reStub.FindStringSubmatch(member.Name())
+						if nil == words {
+							continue
+						}
+						if object, ok := tm.graphqlTypes[words[1]].(*graphql.Object); ok {
+							members[i] = object
+							log.Infof(`%v %v Replaced member %v of %v.`, tm.indent(), tm.level, member.Name(), obj.Name())
+						}
+					}
 				}
 			}
 			tm.parentTypes = map[string]bool{}
@@ -487,12 +535,29 @@ func (tm *typeMapper) goToGraphqlType(goStruct interface{}) (graphqlType graphql
 	}()
 
 	numFieldsMarshalled := 0
-	for fieldNumber := 0; fieldNumber < structure.NumField(); fieldNumber++ {
-		structField := structure.Field(fieldNumber)
+	excluded := []string{}
+	for fieldNumber, structField := range flattenedFields(structure) {
 		log.Infof("%v %v %v %v.%v", tm.indent(), tm.level, fieldNumber, structureName, structField.Name)
+		if "" != structField.PkgPath {
+			excluded = append(excluded, tm.exclude(structureName, structField.Name, "it is not exported").String())
+			continue
+		}
+		fieldName, skip := tm.fieldName(structField)
+		if skip {
+			log.Infof(`%vIgnoring "%v.%v"; it is named "-"`, tm.indent(), structureName, structField.Name)
+			continue
+		}
+		if fieldNameTaken(fields, fieldName) {
+			reason := fmt.Sprintf(`the name "%v" is taken`, fieldName)
+			excluded = append(excluded, tm.exclude(structureName, structField.Name, reason).String())
+			continue
+		}
 		graphqlFieldType, err := tm.goFieldToGraphqlType(structField, structureName)
+		if invalidDefault := (defaultError{}); errors.As(err, &invalidDefault) {
+			return nil, err
+		}
 		if nil != err {
-			log.Infof(`"%v"Ignoring "%v.%v"; reason; %v`, tm.indent(), structureName, structField.Name, err)
+			excluded = append(excluded, tm.exclude(structureName, structField.Name, err.Error()).String())
 			err = nil
 			continue
 		}
@@ -500,37 +565,87 @@ func (tm *typeMapper) goToGraphqlType(goStruct interface{}) (graphqlType graphql
 		if words := strings.Split(structField.Type.String(), "."); len(words) > 1 {
 			fieldType = words[1]
 		}
-		if required := structField.Tag.Get("required"); "true" == required && structField.Type.Kind() == reflect.Ptr {
-			graphqlFieldType = graphql.NewNonNull(graphqlFieldType)
-		}
+		graphqlFieldType = wrapNonNull(graphqlFieldType, tm.nonNull(structField.Type, structField.Tag.Get(RequiredTag)))
 		substituteTypeName := structField.Tag.Get(ReplaceTypeWith)
 		description := structField.Tag.Get("description")
 		switch fields := fields.(type) {
 		case graphql.Fields:
-			fields[structField.Name] = &graphql.Field{
-				Name:        structField.Name,
-				Type:        graphqlFieldType,
-				Description: description,
-				Resolve:     tm.fieldResolverFinder.GetResolver(fieldType, substituteTypeName),
+			resolve := tm.fieldResolverFinder.GetResolver(fieldType, substituteTypeName)
+			if nil == resolve && (1 < len(structField.Index) || fieldName != structField.Name) {
+				resolve = resolveFieldByIndex(structure, structField.Index)
+			}
+			if isMapType(structField.Type) && nil == tm.registeredScalar(structField.Type) {
+				resolve = resolveMapEntries(resolve, tm.substituted(structField))
+			}
+			if tm.pointsToScalar(structField.Type) {
+				resolve = resolveDereferenced(resolve)
+			}
+			args := graphql.FieldConfigArgument{}
+			if "true" == structField.Tag.Get(SortableTag) {
+				if sortableArgs, err := tm.sortableArgs(structField); nil != err {
+					log.Warnf(`%vField "%v.%v" has no orderBy argument; %v`, tm.indent(), structureName, structField.Name, err)
+				} else {
+					args = sortableArgs
+					resolve = tm.resolveSorted(resolve)
+				}
+			}
+			if tm.isConnection(structField) {
+				if connectionType, err := tm.connectionType(graphqlFieldType); nil != err {
+					log.Warnf(`%vField "%v.%v" is not a connection; %v`, tm.indent(), structureName, structField.Name, err)
+				} else {
+					graphqlFieldType = connectionType
+					for name, arg := range connectionArgs() {
+						args[name] = arg
+					}
+					resolve = resolveConnection(resolve)
+				}
+			}
+			fields[fieldName] = &graphql.Field{
+				Name:              fieldName,
+				Type:              graphqlFieldType,
+				Args:              args,
+				Description:       description,
+				DeprecationReason: structField.Tag.Get(DeprecatedTag),
+				Resolve:           resolve,
 			}
 			numFieldsMarshalled = len(fields)
 		case graphql.InputObjectConfigFieldMap:
-			fields[structField.Name] = &graphql.InputObjectFieldConfig{
+			var defaultValue interface{}
+			if literal, ok := structField.Tag.Lookup(DefaultTag); ok {
+				if defaultValue, err = parseDefault(literal, graphqlFieldType); nil != err {
+					err = defaultError{fmt.Errorf(`field "%v.%v"; %v`, structureName, structField.Name, err)}
+					log.Error(err)
+					return nil, err
+				}
+			}
+			fields[fieldName] = &graphql.InputObjectFieldConfig{
 				Type:         graphqlFieldType,
-				DefaultValue: nil,
+				DefaultValue: defaultValue,
 				Description:  description,
 			}
 			numFieldsMarshalled = len(fields)
 		}
 	}
+	if fields, ok := fields.(graphql.Fields); ok && !tm.ignoreMethods {
+		tm.goMethodsToGraphqlFields(structure, structureName, fields)
+		numFieldsMarshalled = len(fields)
+	}
 	log.Info(tm.indent(), "end reflecting on ", structureName)
 	if 0 == numFieldsMarshalled {
-		err = fmt.Errorf(`struct "%v" had 0 marshalable fields; skipping it`, structureName)
+		if 0 == len(excluded) {
+			err = fmt.Errorf(`struct "%v" had 0 marshalable fields; skipping it`, structureName)
+		} else {
+			err = fmt.Errorf(`struct "%v" had 0 marshalable fields; skipping it; excluded %v`, structureName, strings.Join(excluded, "; "))
+		}
 		return
 	}
 	switch fields := fields.(type) {
 	case graphql.Fields:
-		graphqlType = graphql.NewObject(graphql.ObjectConfig{Name: structureName, Fields: fields})
+		graphqlType = graphql.NewObject(graphql.ObjectConfig{
+			Name:       structureName,
+			Fields:     fields,
+			Interfaces: tm.interfacesThunk(structureName),
+		})
 	case graphql.InputObjectConfigFieldMap:
 		graphqlType = graphql.NewInputObject(graphql.InputObjectConfig{Name: structureName, Fields: fields})
 	}
@@ -538,11 +653,14 @@ func (tm *typeMapper) goToGraphqlType(goStruct interface{}) (graphqlType graphql
 	return
 }
 
-func (tm typeMapper) goFieldToGraphqlType(structField reflect.StructField, structName string) (output graphql.Type, err error) {
+func (tm *typeMapper) goFieldToGraphqlType(structField reflect.StructField, structName string) (output graphql.Type, err error) {
 	structFieldType := structField.Type
 	if structFieldType.Kind() == reflect.Ptr {
 		structFieldType = structFieldType.Elem()
 	}
+	if reflect.Map == structFieldType.Kind() && nil == tm.registeredScalar(structFieldType) {
+		return tm.goMapToGraphqlType(structFieldType, structField, structName)
+	}
 
 	t := structFieldType
 	substituteTypeName := structField.Tag.Get(ReplaceTypeWith)
@@ -554,14 +672,16 @@ func (tm typeMapper) goFieldToGraphqlType(structField reflect.StructField, struc
 			tm.indent(), structName, structFieldType.Name(), structField.Name, (*substitutedType).Name(),
 		)
 	}
-	switch t {
-	case reflect.TypeOf(primitive.ObjectID{}):
-		output = ObjectID
-		return
-	case reflect.TypeOf(time.Time{}):
-		output = graphql.DateTime
+	if scalar := tm.registeredScalar(t); nil != scalar {
+		output = scalar
 		return
 	}
+	if reflect.Slice != t.Kind() && reflect.Array != t.Kind() {
+		enum, err := tm.goTypeToGraphqlEnum(t, structField, structName)
+		if nil != err || nil != enum {
+			return enum, err
+		}
+	}
 
 	switch structFieldType.Kind() {
 	case reflect.Struct:
@@ -569,42 +689,53 @@ func (tm typeMapper) goFieldToGraphqlType(structField reflect.StructField, struc
 			return tm.goToGraphqlType(*substitutedType)
 		}
 		return tm.goToGraphqlType(structFieldType)
-	case reflect.Slice:
-		structFieldType = structFieldType.Elem()
+	case reflect.Slice, reflect.Array:
+		elementType := structFieldType.Elem()
+		structFieldType = elementType
+		if reflect.Ptr == structFieldType.Kind() {
+			structFieldType = structFieldType.Elem()
+		}
 		if nil != substitutedType {
 			structFieldType = *substitutedType
 		}
-		switch structFieldType.Kind() {
-		case reflect.Struct:
-			output, err = tm.goToGraphqlType(structFieldType)
-			if nil != err {
+		var element graphql.Type
+		var enum *graphql.Enum
+		if enum, err = tm.goTypeToGraphqlEnum(structFieldType, structField, structName); nil != err {
+			return
+		}
+		switch scalar := tm.registeredScalar(structFieldType); {
+		case nil != scalar:
+			element = scalar
+			log.Info(tm.indent(), structFieldType.Name(), " will be a list of a registered scalar")
+		case nil != enum:
+			element = enum
+			log.Info(tm.indent(), structFieldType.Name(), " will be a list of an enum")
+		case reflect.Struct == structFieldType.Kind():
+			if element, err = tm.goToGraphqlType(structFieldType); nil != err {
 				return
 			}
 			log.Info(tm.indent(), structFieldType, " will be a list of a struct.")
-			output = graphql.NewList(output)
-			return
-		case reflect.Interface:
-			output, err = tm.faceToAny(structFieldType)
-			if nil != err {
+		case reflect.Interface == structFieldType.Kind():
+			if element, err = tm.goInterfaceToGraphqlType(structFieldType, structField); nil != err {
 				return
 			}
 			log.Info(tm.indent(), structFieldType.Name(), " will be a list of an interface")
-			output = graphql.NewList(output)
-			return
 		default:
-			output, err = tm.kindToGraphqlScalar(structFieldType.Kind(), structField.Name)
-			if nil != err {
+			if element, err = tm.kindToGraphqlScalar(structFieldType.Kind(), structField.Name); nil != err {
 				return
 			}
 			log.Info(tm.indent(), structFieldType.Name(), " will be a list of a scalar")
-			output = graphql.NewList(output)
-			return
 		}
+		output = graphql.NewList(wrapNonNull(element, tm.nonNull(elementType, structField.Tag.Get(RequiredElementsTag))))
+		return
 	case reflect.Interface:
 		if nil != substitutedType {
 			structFieldType = *substitutedType
 		}
-		return tm.faceToAny(structFieldType)
+		if reflect.Struct == structFieldType.Kind() {
+			return tm.goToGraphqlType(structFieldType)
+		}
+		return tm.goInterfaceToGraphqlType(structFieldType, structField)
 	}
 	if nil != substitutedType {
 		structFieldType = *substitutedType
@@ -613,20 +744,6 @@ func (tm typeMapper) goFieldToGraphqlType(structField reflect.StructField, struc
 	return
 }
 
-func (tm *typeMapper) faceToAny(Type reflect.Type) (output graphql.Output, err error) {
-	//	output = graphql.NewObject(graphql.ObjectConfig{})
-	methodCount := Type.NumMethod()
-
-	// following does not always work and so is disabled
-	if true == false && 0 != methodCount {
-		Type = Type.Method(0).Type.Out(0)
-		log.Printf("%vhackinglly using the return type from method 0 %v %T;", tm.indent(), Type, Type)
-		return tm.goToGraphqlType(Type.(reflect.Type))
-	}
-	output = Any
-	return
-}
-
 func (tm *typeMapper) kindToGraphqlScalar(kind reflect.Kind, fieldName string) (scalar *graphql.Scalar, err error) {
 
 	switch kind {
@@ -644,6 +761,9 @@ func (tm *typeMapper) kindToGraphqlScalar(kind reflect.Kind, fieldName string) (
 
 	case reflect.Int64:
 		scalar = Int64
+		if tm.int64AsString {
+			scalar = int64AsString
+		}
 
 	case reflect.Uint:
 		fallthrough
@@ -656,6 +776,9 @@ func (tm *typeMapper) kindToGraphqlScalar(kind reflect.Kind, fieldName string) (
 
 	case reflect.Uint64:
 		scalar = Uint64
+		if tm.int64AsString {
+			scalar = uint64AsString
+		}
 		//baseInput(htmlInfo, crumbs, fieldName)
 
 	case reflect.Float32:
@@ -666,16 +789,15 @@ func (tm *typeMapper) kindToGraphqlScalar(kind reflect.Kind, fieldName string) (
 	case reflect.String:
 		scalar = graphql.String
 
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		err = fmt.Errorf("fields of kind %v cannot be resolved", kind)
+
 	case reflect.Complex64:
 		fallthrough
 	case reflect.Complex128:
 		fallthrough
 	case reflect.Array:
 		fallthrough
-	case reflect.Chan:
-		fallthrough
-	case reflect.Func:
-		fallthrough
 	case reflect.Map:
 		fallthrough
 	default:
@@ -702,26 +824,45 @@ var notImplemented = graphql.NewScalar(graphql.ScalarConfig{
 })
 
 func coerceInt64(value interface{}) interface{} {
-	if v, ok := value.(int64); ok {
-		return v
+	i, err := toInt64(value)
+	if nil != err {
+		log.Infof("Int64: %v", err)
+		return nil
+	}
+	return i
+}
+
+func parseInt64Literal(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.IntValue:
+		return coerceInt64(valueAST.Value)
+	case *ast.StringValue:
+		return coerceInt64(valueAST.Value)
 	}
 	return nil
 }
 
 // Int64 reflects the Go Int64 to a graphql output type and vice versa.
+// It parses integers of any Go kind, integral float64 values, json.Number and decimal strings that are in range.
 var Int64 = graphql.NewScalar(graphql.ScalarConfig{
-	Name:       "Int64",
-	Serialize:  coerceInt64,
-	ParseValue: coerceInt64,
-	ParseLiteral: func(valueAST ast.Value) interface{} {
-		switch valueAST := valueAST.(type) {
-		case *ast.IntValue:
-			if i, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
-				return i
-			}
+	Name:         "Int64",
+	Serialize:    coerceInt64,
+	ParseValue:   coerceInt64,
+	ParseLiteral: parseInt64Literal,
+})
+
+// int64AsString is Int64 serialized as a decimal string, for clients that cannot represent integers above 2^53.
+var int64AsString = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Int64",
+	Description: "A 64 bit integer.  It is serialized as a decimal string.",
+	Serialize: func(value interface{}) interface{} {
+		if i, ok := coerceInt64(value).(int64); ok {
+			return strconv.FormatInt(i, 10)
 		}
 		return nil
 	},
+	ParseValue:   coerceInt64,
+	ParseLiteral: parseInt64Literal,
 })
 
 // ObjectID reflects the bson ObjectID to a graphql output type and vice versa.
@@ -757,29 +898,51 @@ var ObjectID = graphql.NewScalar(graphql.ScalarConfig{
 })
 
 func coerceUint64(value interface{}) interface{} {
-	if v, ok := value.(uint64); ok {
-		return v
+	u, err := toUint64(value)
+	if nil != err {
+		log.Infof("Uint64: %v", err)
+		return nil
+	}
+	return u
+}
+
+func parseUint64Literal(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.IntValue:
+		return coerceUint64(valueAST.Value)
+	case *ast.StringValue:
+		return coerceUint64(valueAST.Value)
 	}
 	return nil
 }
 
 // Uint64 reflects the Go Uint64 kind to a graphql output type and vice versa.
+// It parses integers of any Go kind, integral float64 values, json.Number and decimal strings that are in range.
 var Uint64 = graphql.NewScalar(graphql.ScalarConfig{
-	Name:       "Uint64",
-	Serialize:  coerceUint64,
-	ParseValue: coerceUint64,
-	ParseLiteral: func(valueAST ast.Value) interface{} {
-		switch valueAST := valueAST.(type) {
-		case *ast.IntValue:
-			if i, err := strconv.ParseUint(valueAST.Value, 10, 64); err == nil {
-				return i
-			}
+	Name:         "Uint64",
+	Serialize:    coerceUint64,
+	ParseValue:   coerceUint64,
+	ParseLiteral: parseUint64Literal,
+})
+
+// uint64AsString is Uint64 serialized as a decimal string, for clients that cannot represent integers above 2^53.
+var uint64AsString = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Uint64",
+	Description: "A 64 bit unsigned integer.  It is serialized as a decimal string.",
+	Serialize: func(value interface{}) interface{} {
+		if u, ok := coerceUint64(value).(uint64); ok {
+			return strconv.FormatUint(u, 10)
 		}
 		return nil
 	},
+	ParseValue:   coerceUint64,
+	ParseLiteral: parseUint64Literal,
 })
 
 // Any reflects the Go lang interface Kind to a string of a JSON document and vice versa.
+// It parses JSON documents in strings, and JSON values.
+//
+// Deprecated: use JSON, which serializes values as JSON values rather than as strings.
 var Any = graphql.NewScalar(graphql.ScalarConfig{
 	Name: "Any",
 	Serialize: func(value interface{}) interface{} {
@@ -789,22 +952,95 @@ var Any = graphql.NewScalar(graphql.ScalarConfig{
 		return nil
 	},
 	ParseValue: func(value interface{}) interface{} {
-		var v map[string]interface{}
-		if err := json.Unmarshal(value.([]byte), &v); nil != err {
-			return v
+		if document, ok := value.(string); ok {
+			return unmarshalJSON([]byte(document))
 		}
-		return nil
+		return parseJSON(value)
 	},
 	ParseLiteral: func(valueAST ast.Value) interface{} {
-		value := valueAST.GetValue()
-		var v map[string]interface{}
-		if err := json.Unmarshal(value.([]byte), &v); nil != err {
-			return v
+		if document, ok := valueAST.(*ast.StringValue); ok {
+			return unmarshalJSON([]byte(document.Value))
 		}
-		return nil
+		return jsonFromAST(valueAST)
 	},
 })
 
+// JSON reflects Go values, such as a map[string]interface{}, to JSON values and vice versa.
+// Values other than those that encoding/json decodes to, for example structs and json.RawMessage, are serialized the
+// way that encoding/json encodes them.  It parses any JSON value, from variables and from literals.
+var JSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "A JSON value.",
+	Serialize: func(value interface{}) interface{} {
+		switch value.(type) {
+		case nil, bool, string, float64, json.Number, map[string]interface{}, []interface{}:
+			return value
+		}
+		document, err := json.Marshal(value)
+		if nil != err {
+			log.Errorf("JSON: %v", err)
+			return nil
+		}
+		return unmarshalJSON(document)
+	},
+	ParseValue: parseJSON,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return jsonFromAST(valueAST)
+	},
+})
+
+// parseJSON returns the value of a variable; the value decoded from it when it is a json.RawMessage or []byte.
+func parseJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case json.RawMessage:
+		return unmarshalJSON(value)
+	case []byte:
+		return unmarshalJSON(value)
+	}
+	return value
+}
+
+// unmarshalJSON returns the value of the JSON document, with numbers as json.Number; nil when it is not valid.
+func unmarshalJSON(document []byte) (value interface{}) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); nil != err {
+		log.Infof("JSON: %v", err)
+		return nil
+	}
+	return
+}
+
+// jsonFromAST returns the Go value of a literal JSON value.  Numbers are json.Number, as unmarshalJSON decodes them, so
+// that integers of any size keep their precision.
+func jsonFromAST(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		object := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			object[field.Name.Value] = jsonFromAST(field.Value)
+		}
+		return object
+	case *ast.ListValue:
+		list := make([]interface{}, 0, len(valueAST.Values))
+		for _, value := range valueAST.Values {
+			list = append(list, jsonFromAST(value))
+		}
+		return list
+	case *ast.IntValue:
+		return json.Number(valueAST.Value)
+	case *ast.FloatValue:
+		return json.Number(valueAST.Value)
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	}
+	return nil
+}
+
 func null(value interface{}) interface{} {
 	return nil
 }
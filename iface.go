@@ -0,0 +1,243 @@
+package gographql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+// RegisterImplementations causes fields of a Go interface type to be translated to a graphql interface.
+// Each implementation is translated to an output type that implements the graphql interface, and the
+// graphql interface has the fields that all of those output types have in common.
+// iface is a pointer to the interface type, for example (*types.BaseDatastoreInfo)(nil), or its reflect.Type.
+// An implementation is a struct value, a pointer to one, or its reflect.Type.  Registering an implementation again has no effect.
+func RegisterImplementations(iface interface{}, implementations ...interface{}) (err error) {
+	return objectMapper.RegisterImplementations(iface, implementations...)
+}
+
+// RegisterImplementations causes fields of a Go interface type to be translated to a graphql interface.
+// Each implementation is translated to an output type that implements the graphql interface, and the
+// graphql interface has the fields that all of those output types have in common.
+// iface is a pointer to the interface type, for example (*types.BaseDatastoreInfo)(nil), or its reflect.Type.
+// An implementation is a struct value, a pointer to one, or its reflect.Type.  Registering an implementation again has no effect.
+func (tm *typeMapper) RegisterImplementations(iface interface{}, implementations ...interface{}) (err error) {
+	ifaceType := typeOf(iface)
+	if nil == ifaceType || reflect.Interface != ifaceType.Kind() || "" == ifaceType.Name() {
+		err = fmt.Errorf("%v is not a named interface type", ifaceType)
+		log.Error(err)
+		return
+	}
+	if 0 == len(implementations) {
+		err = fmt.Errorf("interface %v must have at least one implementation", ifaceType)
+		log.Error(err)
+		return
+	}
+	for _, implementation := range implementations {
+		implType := typeOf(implementation)
+		if nil == implType || reflect.Struct != implType.Kind() {
+			err = fmt.Errorf("implementation %v of interface %v is not a struct", implType, ifaceType)
+			log.Error(err)
+			return
+		}
+		if !reflect.PtrTo(implType).Implements(ifaceType) {
+			err = fmt.Errorf("%v does not implement %v", implType, ifaceType)
+			log.Error(err)
+			return
+		}
+		if tm.implements(ifaceType, implType) {
+			log.Infof("%v is already registered as an implementation of %v", implType, ifaceType)
+			continue
+		}
+		tm.implementations[ifaceType] = append(tm.implementations[ifaceType], implType)
+	}
+	return
+}
+
+// implements reports whether implType is registered as an implementation of ifaceType.
+func (tm *typeMapper) implements(ifaceType, implType reflect.Type) bool {
+	for _, registered := range tm.implementations[ifaceType] {
+		if implType == registered {
+			return true
+		}
+	}
+	return false
+}
+
+// ImplementationTypes returns the output types of the implementations of the interfaces that have been translated.
+// A schema must include them in graphql.SchemaConfig.Types since they may not be reachable from the schema's roots.
+// SchemaBuilder includes them.
+func ImplementationTypes() []graphql.Type {
+	return objectMapper.ImplementationTypes()
+}
+
+// ImplementationTypes returns the output types of the implementations of the interfaces that have been translated.
+// A schema must include them in graphql.SchemaConfig.Types since they may not be reachable from the schema's roots.
+// SchemaBuilder includes them.
+func (tm *typeMapper) ImplementationTypes() (types []graphql.Type) {
+	names := []string{}
+	for name := range tm.objectInterfaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if object, ok := tm.graphqlTypes[name].(*graphql.Object); ok {
+			types = append(types, object)
+		}
+	}
+	return
+}
+
+// typeOf returns the type of value, or value itself when it is a reflect.Type, dereferencing pointers.
+func typeOf(value interface{}) (Type reflect.Type) {
+	Type, ok := value.(reflect.Type)
+	if !ok {
+		Type = reflect.TypeOf(value)
+	}
+	if nil != Type && reflect.Ptr == Type.Kind() {
+		Type = Type.Elem()
+	}
+	return
+}
+
+// goInterfaceToGraphqlType translates a Go interface type to the union that is declared for the field, or to a
+// graphql interface when it has registered implementations.  Otherwise, and for input types, the JSON scalar is returned.
+func (tm *typeMapper) goInterfaceToGraphqlType(Type reflect.Type, structField reflect.StructField) (output graphql.Output, err error) {
+	if graphqlInput == tm.targetType {
+		output = JSON
+		return
+	}
+	union, err := tm.goUnionToGraphqlType(Type, structField)
+	if nil != err {
+		return
+	}
+	if nil != union {
+		output = union
+		return
+	}
+	implementations, registered := tm.implementations[Type]
+	if !registered {
+		output = JSON
+		return
+	}
+	if iface, defined := tm.interfaces[Type]; defined {
+		return iface, nil
+	}
+	if 0 == len(implementations) {
+		err = errors.New("interface has no implementations")
+		return
+	}
+	implementationNames := map[reflect.Type]string{}
+	names := []string{}
+	for _, implementation := range implementations {
+		implementationNames[implementation] = implementation.Name()
+		names = append(names, implementation.Name())
+	}
+	graphqlTypes := tm.graphqlTypes
+	iface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: Type.Name(),
+		// the fields are gathered once all of the implementations are translated.
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return commonFields(graphqlTypes, names)
+		}),
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			valueType := typeOf(p.Value)
+			if nil == valueType {
+				return nil
+			}
+			name, ok := implementationNames[valueType]
+			if !ok {
+				log.Errorf("%v is not a registered implementation of %v", valueType, Type)
+				return nil
+			}
+			object, _ := graphqlTypes[name].(*graphql.Object)
+			return object
+		},
+	})
+	// declared before translating the implementations since they may refer to the interface.
+	tm.interfaces[Type] = iface
+	for i, implementation := range implementations {
+		tm.objectInterfaces[implementation.Name()] = append(tm.objectInterfaces[implementation.Name()], iface)
+		if _, err = tm.goToGraphqlType(implementation); nil != err {
+			delete(tm.interfaces, Type)
+			for _, translated := range implementations[:i+1] {
+				tm.removeObjectInterface(translated.Name(), iface)
+			}
+			return
+		}
+		log.Infof("%v%v implements %v", tm.indent(), implementation.Name(), Type.Name())
+	}
+	output = iface
+	return
+}
+
+// removeObjectInterface removes iface from the interfaces that the named object implements.
+func (tm *typeMapper) removeObjectInterface(name string, iface *graphql.Interface) {
+	remaining := []*graphql.Interface{}
+	for _, objectInterface := range tm.objectInterfaces[name] {
+		if objectInterface != iface {
+			remaining = append(remaining, objectInterface)
+		}
+	}
+	if 0 == len(remaining) {
+		delete(tm.objectInterfaces, name)
+		return
+	}
+	tm.objectInterfaces[name] = remaining
+}
+
+// interfaceErrors returns an error for each translated interface whose implementations have no fields in common,
+// since a graphql interface must have fields.  The fields are gathered once all of the types are translated.
+func (tm *typeMapper) interfaceErrors() (errs SchemaErrors) {
+	names := []string{}
+	byName := map[string]*graphql.Interface{}
+	for _, iface := range tm.interfaces {
+		names = append(names, iface.Name())
+		byName[iface.Name()] = iface
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if 0 == len(byName[name].Fields()) {
+			errs = append(errs, fmt.Errorf("interface %v: its implementations have no fields in common", name))
+		}
+	}
+	return
+}
+
+// commonFields returns the fields that all of the named objects have.
+func commonFields(graphqlTypes map[string]graphql.Type, names []string) (common graphql.Fields) {
+	for _, name := range names {
+		object, ok := graphqlTypes[name].(*graphql.Object)
+		if !ok {
+			continue
+		}
+		objectFields := object.Fields()
+		if nil == common {
+			common = graphql.Fields{}
+			for fieldName, fieldDef := range objectFields {
+				field := fieldFromDefinition(fieldDef)
+				field.Resolve = nil
+				common[fieldName] = field
+			}
+			continue
+		}
+		for fieldName, field := range common {
+			fieldDef, ok := objectFields[fieldName]
+			if !ok || fieldDef.Type.String() != field.Type.String() {
+				delete(common, fieldName)
+			}
+		}
+	}
+	return
+}
+
+// interfacesThunk returns the interfaces that the named object implements.
+// It is a thunk since implementations may be registered after the object is translated.
+func (tm *typeMapper) interfacesThunk(name string) graphql.InterfacesThunk {
+	objectInterfaces := tm.objectInterfaces
+	return func() []*graphql.Interface {
+		return objectInterfaces[name]
+	}
+}
@@ -0,0 +1,91 @@
+package gographql
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Square struct {
+	Side float64
+}
+
+func (s *Square) Area() float64 { return s.Side * s.Side }
+
+type Circle struct {
+	Radius float64
+}
+
+func (c *Circle) Area() float64 { return 3 * c.Radius * c.Radius }
+
+type Blank struct {
+	hidden float64
+}
+
+func (b *Blank) Area() float64 { return b.hidden }
+
+type Drawing struct {
+	Shape Shape
+}
+
+func TestInterfaceWithoutCommonFields(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.IgnoreMethods("Area")
+	if err := tm.RegisterImplementations((*Shape)(nil), Square{}, Circle{}); nil != err {
+		t.Fatal(err)
+	}
+	_, err := tm.NewSchemaBuilder().Query(Drawing{}).Build()
+	if nil == err || !strings.Contains(err.Error(), "interface Shape: its implementations have no fields in common") {
+		t.Errorf("got %v; expected an error that the implementations have no fields in common", err)
+	}
+}
+
+func TestInterfaceImplementationError(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.IgnoreMethods("Area")
+	if err := tm.RegisterImplementations((*Shape)(nil), Square{}, Blank{}); nil != err {
+		t.Fatal(err)
+	}
+	if _, err := tm.GoToGraphqlOutput(Drawing{}); nil == err {
+		t.Fatal("expected an error translating Blank")
+	}
+	if 0 != len(tm.objectInterfaces) || 0 != len(tm.interfaces) {
+		t.Errorf("got %v and %v; expected the interface to be unregistered", tm.objectInterfaces, tm.interfaces)
+	}
+}
+
+func TestRegisterImplementationsTwice(t *testing.T) {
+	tm := NewTypeMapper()
+	if err := tm.RegisterImplementations((*Shape)(nil), Square{}, Circle{}); nil != err {
+		t.Fatal(err)
+	}
+	if err := tm.RegisterImplementations((*Shape)(nil), &Square{}, reflect.TypeOf(Circle{}), Square{}); nil != err {
+		t.Fatal(err)
+	}
+	schema, err := tm.NewSchemaBuilder().Query(Drawing{}).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	shape, ok := schema.Type("Shape").(*graphql.Interface)
+	if !ok {
+		t.Fatalf("got %v; expected the interface Shape", schema.Type("Shape"))
+	}
+	names := []string{}
+	for _, object := range schema.PossibleTypes(shape) {
+		names = append(names, object.Name())
+	}
+	sort.Strings(names)
+	if expected := []string{"Circle", "Square"}; !reflect.DeepEqual(expected, names) {
+		t.Errorf("got the possible types %v; expected %v", names, expected)
+	}
+	if types := tm.ImplementationTypes(); 2 != len(types) {
+		t.Errorf("got the implementation types %v; expected Circle and Square", types)
+	}
+}
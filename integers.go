@@ -0,0 +1,96 @@
+package gographql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SetInt64AsString sets whether Int64 and Uint64 values are serialized as decimal strings; for example for
+// JavaScript clients, which lose the precision of integers above 2^53.  Either form is parsed.
+// It must be set before any type is translated.
+func SetInt64AsString(asString bool) (err error) {
+	return objectMapper.SetInt64AsString(asString)
+}
+
+// SetInt64AsString sets whether Int64 and Uint64 values are serialized as decimal strings; for example for
+// JavaScript clients, which lose the precision of integers above 2^53.  Either form is parsed.
+// It must be set before any type is translated, since a schema cannot have both forms of the scalars; it is an error
+// to change it after.
+func (tm *typeMapper) SetInt64AsString(asString bool) (err error) {
+	if asString != tm.int64AsString && 0 != len(tm.graphqlTypes) {
+		return errors.New("SetInt64AsString must be set before any type is translated")
+	}
+	tm.int64AsString = asString
+	return
+}
+
+// toInt64 returns value as an int64.  value is an integer of any kind, an integral float, a json.Number or a decimal string.
+func toInt64(value interface{}) (i int64, err error) {
+	switch v := value.(type) {
+	case json.Number:
+		return toInt64(string(v))
+	case string:
+		if i, err = strconv.ParseInt(strings.TrimSpace(v), 10, 64); nil != err {
+			err = fmt.Errorf("%q is not an integer in the range of int64", v)
+		}
+		return
+	}
+	source := reflect.ValueOf(value)
+	if reflect.Ptr == source.Kind() && !source.IsNil() {
+		source = source.Elem()
+	}
+	switch source.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return source.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if source.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%v overflows int64", source.Uint())
+		}
+		return int64(source.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := source.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an integer in the range of int64", f)
+		}
+		return int64(f), nil
+	}
+	return 0, fmt.Errorf("cannot coerce %T to int64", value)
+}
+
+// toUint64 returns value as a uint64.  value is an integer of any kind, an integral float, a json.Number or a decimal string.
+func toUint64(value interface{}) (u uint64, err error) {
+	switch v := value.(type) {
+	case json.Number:
+		return toUint64(string(v))
+	case string:
+		if u, err = strconv.ParseUint(strings.TrimSpace(v), 10, 64); nil != err {
+			err = fmt.Errorf("%q is not an integer in the range of uint64", v)
+		}
+		return
+	}
+	source := reflect.ValueOf(value)
+	if reflect.Ptr == source.Kind() && !source.IsNil() {
+		source = source.Elem()
+	}
+	switch source.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if source.Int() < 0 {
+			return 0, fmt.Errorf("%v overflows uint64", source.Int())
+		}
+		return uint64(source.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return source.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		f := source.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, fmt.Errorf("%v is not an integer in the range of uint64", f)
+		}
+		return uint64(f), nil
+	}
+	return 0, fmt.Errorf("cannot coerce %T to uint64", value)
+}
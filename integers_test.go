@@ -0,0 +1,28 @@
+package gographql
+
+import "testing"
+
+type counters struct {
+	Big   int64
+	Large uint64
+}
+
+func TestSetInt64AsString(t *testing.T) {
+	tm := NewTypeMapper()
+	if err := tm.SetInt64AsString(true); nil != err {
+		t.Fatal(err)
+	}
+	schema, err := tm.NewSchemaBuilder().Query(counters{Big: 1 << 60, Large: 1 << 63}).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if data, expected := execute(t, schema, `{Big Large}`, nil), `{"Big":"1152921504606846976","Large":"9223372036854775808"}`; expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+	if err = tm.SetInt64AsString(false); nil == err {
+		t.Error("expected an error changing it after types are translated")
+	}
+	if err = tm.SetInt64AsString(true); nil != err {
+		t.Errorf("got %v; expected no error setting it to the same value", err)
+	}
+}
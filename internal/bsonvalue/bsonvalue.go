@@ -0,0 +1,277 @@
+// Package bsonvalue compares and looks up the values of bson documents the way Mongo does.
+package bsonvalue
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Normalize returns the document as it is stored; its values have the Go types that bson decodes them to.
+func Normalize(document interface{}) (normalized bson.D, err error) {
+	raw, err := bson.Marshal(document)
+	if nil != err {
+		return
+	}
+	err = bson.Unmarshal(raw, &normalized)
+	return
+}
+
+// Decode decodes the document into the value that target points to.
+func Decode(document bson.D, target interface{}) (err error) {
+	raw, err := bson.Marshal(document)
+	if nil != err {
+		return
+	}
+	return bson.Unmarshal(raw, target)
+}
+
+// AsDocument returns value as a bson.D when it is a document; the keys of a bson.M are ordered by name.
+func AsDocument(value interface{}) (document bson.D, ok bool) {
+	switch value := value.(type) {
+	case bson.D:
+		return value, true
+	case bson.M:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			document = append(document, bson.E{Key: key, Value: value[key]})
+		}
+		return document, true
+	}
+	return
+}
+
+// TypeOrder returns the rank of the BSON type of value in the order of Mongo's comparisons.
+func TypeOrder(value interface{}) int {
+	switch value.(type) {
+	case primitive.MinKey:
+		return 0
+	case nil, primitive.Null, primitive.Undefined:
+		return 1
+	case int32, int64, float64, primitive.Decimal128:
+		return 2
+	case string, primitive.Symbol:
+		return 3
+	case bson.D, bson.M:
+		return 4
+	case bson.A:
+		return 5
+	case primitive.Binary:
+		return 6
+	case primitive.ObjectID:
+		return 7
+	case bool:
+		return 8
+	case primitive.DateTime:
+		return 9
+	case primitive.Timestamp:
+		return 10
+	case primitive.Regex:
+		return 11
+	case primitive.MaxKey:
+		return 13
+	}
+	return 12
+}
+
+// Compare returns -1, 0 or 1 as a is less than, equal to, or greater than b, in the order of Mongo's comparisons.
+func Compare(a, b interface{}) int {
+	if orderA, orderB := TypeOrder(a), TypeOrder(b); orderA != orderB {
+		return compareInts(int64(orderA), int64(orderB))
+	}
+	switch a := a.(type) {
+	case int32, int64, float64, primitive.Decimal128:
+		return compareNumbers(a, b)
+	case string:
+		return strings.Compare(a, fmt.Sprint(b))
+	case bson.D, bson.M:
+		documentA, _ := AsDocument(a)
+		documentB, _ := AsDocument(b)
+		for i := 0; i < len(documentA) && i < len(documentB); i++ {
+			if order := strings.Compare(documentA[i].Key, documentB[i].Key); 0 != order {
+				return order
+			}
+			if order := Compare(documentA[i].Value, documentB[i].Value); 0 != order {
+				return order
+			}
+		}
+		return compareInts(int64(len(documentA)), int64(len(documentB)))
+	case bson.A:
+		arrayB := b.(bson.A)
+		for i := 0; i < len(a) && i < len(arrayB); i++ {
+			if order := Compare(a[i], arrayB[i]); 0 != order {
+				return order
+			}
+		}
+		return compareInts(int64(len(a)), int64(len(arrayB)))
+	case primitive.Binary:
+		binaryB := b.(primitive.Binary)
+		if len(a.Data) != len(binaryB.Data) {
+			return compareInts(int64(len(a.Data)), int64(len(binaryB.Data)))
+		}
+		if a.Subtype != binaryB.Subtype {
+			return compareInts(int64(a.Subtype), int64(binaryB.Subtype))
+		}
+		return bytes.Compare(a.Data, binaryB.Data)
+	case primitive.ObjectID:
+		idB := b.(primitive.ObjectID)
+		return bytes.Compare(a[:], idB[:])
+	case bool:
+		boolB := b.(bool)
+		switch {
+		case a == boolB:
+			return 0
+		case boolB:
+			return -1
+		}
+		return 1
+	case primitive.DateTime:
+		return compareInts(int64(a), int64(b.(primitive.DateTime)))
+	case primitive.Timestamp:
+		timestampB := b.(primitive.Timestamp)
+		if a.T != timestampB.T {
+			return compareInts(int64(a.T), int64(timestampB.T))
+		}
+		return compareInts(int64(a.I), int64(timestampB.I))
+	case primitive.Regex:
+		regexB := b.(primitive.Regex)
+		if order := strings.Compare(a.Pattern, regexB.Pattern); 0 != order {
+			return order
+		}
+		return strings.Compare(a.Options, regexB.Options)
+	case nil, primitive.Null, primitive.Undefined, primitive.MinKey, primitive.MaxKey:
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareNumbers compares numbers of the BSON numeric types; integers exactly.
+func compareNumbers(a, b interface{}) int {
+	integerA, isIntegerA := bsonInteger(a)
+	integerB, isIntegerB := bsonInteger(b)
+	if isIntegerA && isIntegerB {
+		return compareInts(integerA, integerB)
+	}
+	floatA, floatB := bsonFloat(a), bsonFloat(b)
+	switch {
+	case floatA < floatB:
+		return -1
+	case floatA > floatB:
+		return 1
+	}
+	return 0
+}
+
+func bsonInteger(value interface{}) (integer int64, ok bool) {
+	switch value := value.(type) {
+	case int32:
+		return int64(value), true
+	case int64:
+		return value, true
+	}
+	return
+}
+
+func bsonFloat(value interface{}) float64 {
+	switch value := value.(type) {
+	case int32:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case float64:
+		return value
+	case primitive.Decimal128:
+		f, _ := strconv.ParseFloat(value.String(), 64)
+		return f
+	}
+	return 0
+}
+
+// LookupFirst returns the first value at the dotted path of the document.
+func LookupFirst(document interface{}, path string) (value interface{}, exists bool) {
+	values, exists := LookupPath(document, path)
+	if 0 == len(values) {
+		return nil, exists
+	}
+	return values[0], exists
+}
+
+// LookupPath returns the values at the dotted path of the document; the path leads into the elements of arrays,
+// and an array at the end of the path is returned followed by its elements.
+func LookupPath(document interface{}, path string) (values []interface{}, exists bool) {
+	key := path
+	rest := ""
+	if i := strings.Index(path, "."); 0 <= i {
+		key, rest = path[:i], path[i+1:]
+	}
+	switch document := document.(type) {
+	case bson.D:
+		for _, element := range document {
+			if key == element.Key {
+				return lookupRest(element.Value, rest)
+			}
+		}
+	case bson.M:
+		if value, ok := document[key]; ok {
+			return lookupRest(value, rest)
+		}
+	case bson.A:
+		if isIndex(key) {
+			var index int
+			fmt.Sscan(key, &index)
+			if index < len(document) {
+				return lookupRest(document[index], rest)
+			}
+			return
+		}
+		for _, element := range document {
+			elementValues, elementExists := LookupPath(element, path)
+			values = append(values, elementValues...)
+			exists = exists || elementExists
+		}
+	}
+	return
+}
+
+func lookupRest(value interface{}, rest string) (values []interface{}, exists bool) {
+	if "" != rest {
+		return LookupPath(value, rest)
+	}
+	values = []interface{}{value}
+	if array, ok := value.(bson.A); ok {
+		values = append(values, array...)
+	}
+	return values, true
+}
+
+func isIndex(key string) bool {
+	if "" == key {
+		return false
+	}
+	for _, r := range key {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
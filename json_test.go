@@ -0,0 +1,93 @@
+package gographql
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+type Payload struct {
+	Value interface{}
+}
+
+type Echoer struct{}
+
+func (Echoer) Echo(args Payload) Payload { return args }
+
+func TestJSONSerialize(t *testing.T) {
+	for _, test := range []struct {
+		value    interface{}
+		expected interface{}
+	}{
+		{nil, nil},
+		{"a", "a"},
+		{json.Number("12345678901234567890"), json.Number("12345678901234567890")},
+		{map[string]interface{}{"a": []interface{}{1.5, true}}, map[string]interface{}{"a": []interface{}{1.5, true}}},
+		{Location{City: "Oslo"}, map[string]interface{}{"City": "Oslo"}},
+		{json.RawMessage(`[1, {"b": null}]`), []interface{}{json.Number("1"), map[string]interface{}{"b": nil}}},
+		{int64(7), json.Number("7")},
+		{func() {}, nil},
+	} {
+		if serialized := JSON.Serialize(test.value); !reflect.DeepEqual(test.expected, serialized) {
+			t.Errorf("%#v: got %#v; expected %#v", test.value, serialized, test.expected)
+		}
+	}
+}
+
+func TestJSONParseValue(t *testing.T) {
+	for _, test := range []struct {
+		value    interface{}
+		expected interface{}
+	}{
+		{map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1}},
+		{json.Number("12345678901234567890"), json.Number("12345678901234567890")},
+		{json.RawMessage(`{"a": 12345678901234567890}`), map[string]interface{}{"a": json.Number("12345678901234567890")}},
+		{[]byte(`[true]`), []interface{}{true}},
+		{json.RawMessage(`{`), nil},
+	} {
+		if parsed := JSON.ParseValue(test.value); !reflect.DeepEqual(test.expected, parsed) {
+			t.Errorf("%#v: got %#v; expected %#v", test.value, parsed, test.expected)
+		}
+	}
+}
+
+func TestJSONParseLiteral(t *testing.T) {
+	literal := &ast.ObjectValue{Kind: "ObjectValue", Fields: []*ast.ObjectField{
+		{Name: &ast.Name{Value: "big"}, Value: &ast.IntValue{Value: "12345678901234567890"}},
+		{Name: &ast.Name{Value: "list"}, Value: &ast.ListValue{Values: []ast.Value{
+			&ast.FloatValue{Value: "1.5"},
+			&ast.StringValue{Value: "b"},
+			&ast.BooleanValue{Value: true},
+			&ast.EnumValue{Value: "RED"},
+			&ast.ObjectValue{Fields: []*ast.ObjectField{{Name: &ast.Name{Value: "n"}, Value: &ast.IntValue{Value: "-3"}}}},
+		}}},
+	}}
+	expected := map[string]interface{}{
+		"big":  json.Number("12345678901234567890"),
+		"list": []interface{}{json.Number("1.5"), "b", true, "RED", map[string]interface{}{"n": json.Number("-3")}},
+	}
+	if parsed := JSON.ParseLiteral(literal); !reflect.DeepEqual(expected, parsed) {
+		t.Errorf("got %#v; expected %#v", parsed, expected)
+	}
+}
+
+func TestJSONLiteralsAndVariables(t *testing.T) {
+	tm := NewTypeMapper()
+	schema, err := tm.NewSchemaBuilder().Query(Echoer{}).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	expected := `{"Echo":{"Value":{"big":12345678901234567890,"list":[1,2.5,"c",{"d":true}]}}}`
+	literal := `{Echo(Value: {big: 12345678901234567890, list: [1, 2.5, "c", {d: true}]}) {Value}}`
+	if data := execute(t, schema, literal, nil); expected != data {
+		t.Errorf("literal: got %v; expected %v", data, expected)
+	}
+	// a server decodes variables with UseNumber, as unmarshalJSON does
+	decoded := unmarshalJSON([]byte(`{"big":12345678901234567890,"list":[1,2.5,"c",{"d":true}]}`))
+	variable := `query($value: JSON) {Echo(Value: $value) {Value}}`
+	if data := execute(t, schema, variable, map[string]interface{}{"value": decoded}); expected != data {
+		t.Errorf("variable: got %v; expected %v", data, expected)
+	}
+}
@@ -0,0 +1,181 @@
+package gographql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	"github.com/sssmack/gographql/internal/bsonvalue"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// keysetCursor is what the cursors of the connections of collections encode; the sort keys, prefixed with "-" when they
+// are descending, and the values of them of a document.  The last key is _id, so the values end with the id of the document.
+type keysetCursor struct {
+	Keys   []string `bson:"k"`
+	Values bson.A   `bson:"v"`
+}
+
+// cursorKeys returns the keys of the cursors of the sort.
+func cursorKeys(sort bson.D) (keys []string) {
+	for _, key := range sort {
+		if -1 == key.Value {
+			keys = append(keys, "-"+key.Key)
+		} else {
+			keys = append(keys, key.Key)
+		}
+	}
+	return
+}
+
+// encodeKeysetCursor returns the cursor of the document in the order of sort.  The values are encoded as bson, so that
+// ObjectIDs, dates and numbers are compared as the types that they are stored as.
+func encodeKeysetCursor(sort bson.D, document bson.D) (cursor string, err error) {
+	content := keysetCursor{Keys: cursorKeys(sort), Values: bson.A{}}
+	for _, key := range sort {
+		value, _ := bsonvalue.LookupFirst(document, key.Key)
+		content.Values = append(content.Values, value)
+	}
+	raw, err := bson.Marshal(content)
+	if nil != err {
+		return
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeKeysetCursor returns the values of the sort keys that the cursor encodes.  The cursor must be of the order of sort.
+func decodeKeysetCursor(sort bson.D, cursor string) (values []interface{}, err error) {
+	var content keysetCursor
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if nil == err {
+		err = bson.Unmarshal(raw, &content)
+	}
+	if nil != err || len(content.Keys) != len(content.Values) {
+		return nil, fmt.Errorf("%q is not a valid cursor", cursor)
+	}
+	keys := cursorKeys(sort)
+	if len(keys) != len(content.Keys) {
+		return nil, fmt.Errorf("the cursor %q is of another order", cursor)
+	}
+	for i, key := range keys {
+		if key != content.Keys[i] {
+			return nil, fmt.Errorf("the cursor %q is of another order", cursor)
+		}
+	}
+	return content.Values, nil
+}
+
+// reverseSort returns sort having the directions of the keys reversed.
+func reverseSort(sort bson.D) (reversed bson.D) {
+	for _, key := range sort {
+		direction := 1
+		if 1 == key.Value {
+			direction = -1
+		}
+		reversed = append(reversed, bson.E{Key: key.Key, Value: direction})
+	}
+	return
+}
+
+// collectionConnectionArgs returns the arguments of the connection field of a collection; those of connections, and
+// those that filter and order documents.
+func collectionConnectionArgs(findArgs graphql.FieldConfigArgument) (args graphql.FieldConfigArgument) {
+	args = connectionArgs()
+	for _, name := range []string{"filter", "where", "orderBy", "sort"} {
+		args[name] = findArgs[name]
+	}
+	return
+}
+
+// resolveCollectionConnection returns a resolver of a page of the documents of the collection that match the filter
+// and where arguments, in the order of the orderBy and sort arguments.  The after and before cursors are translated to
+// range filters on the sort keys, which end with _id, and first and last to a limit; documents are not skipped.
+// When paging forward hasPreviousPage is whether there is an after cursor, and when paging backward hasNextPage is
+// whether there is a before cursor.
+func (tm *typeMapper) resolveCollectionConnection(binding *collectionBinding) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		ctx := resolveContext(p)
+		filter, err := tm.bindingFilter(binding, p.Args)
+		if nil != err {
+			return
+		}
+		sort, err := tm.sortDocument(binding, p.Args)
+		if nil != err {
+			return
+		}
+		pageFilter := filter
+		_, hasAfter := p.Args["after"].(string)
+		_, hasBefore := p.Args["before"].(string)
+		for _, bound := range []struct {
+			name string
+			sort bson.D
+		}{{"after", sort}, {"before", reverseSort(sort)}} {
+			cursor, ok := p.Args[bound.name].(string)
+			if !ok {
+				continue
+			}
+			values, err := decodeKeysetCursor(sort, cursor)
+			if nil != err {
+				return nil, fmt.Errorf("%v: %v", bound.name, err)
+			}
+			pageFilter = andFilters(pageFilter, keysetFilter(bound.sort, values))
+		}
+		first, hasFirst := p.Args["first"].(int)
+		last, hasLast := p.Args["last"].(int)
+		if hasFirst && first < 0 {
+			return nil, fmt.Errorf("first cannot be negative; it is %v", first)
+		}
+		if hasLast && last < 0 {
+			return nil, fmt.Errorf("last cannot be negative; it is %v", last)
+		}
+		backward := hasLast && !hasFirst
+		limit := first
+		opts := options.Find().SetSort(sort)
+		if backward {
+			limit = last
+			opts.SetSort(reverseSort(sort))
+		}
+		if hasFirst || backward {
+			opts.SetLimit(int64(limit) + 1)
+		}
+		var documents []bson.D
+		if err = binding.collection.Find(ctx, pageFilter, opts, &documents); nil != err {
+			return
+		}
+		info := pageInfo{hasPreviousPage: hasAfter, hasNextPage: hasBefore}
+		if (hasFirst || backward) && limit < len(documents) {
+			documents = documents[:limit]
+			if backward {
+				info.hasPreviousPage = true
+			} else {
+				info.hasNextPage = true
+			}
+		}
+		if backward {
+			for i, j := 0, len(documents)-1; i < j; i, j = i+1, j-1 {
+				documents[i], documents[j] = documents[j], documents[i]
+			}
+		} else if hasLast && last < len(documents) {
+			documents = documents[len(documents)-last:]
+			info.hasPreviousPage = true
+		}
+		edges := make([]edge, 0, len(documents))
+		for _, document := range documents {
+			node := reflect.New(binding.Type)
+			if err = bsonvalue.Decode(document, node.Interface()); nil != err {
+				return
+			}
+			cursor, err := encodeKeysetCursor(sort, document)
+			if nil != err {
+				return nil, err
+			}
+			edges = append(edges, edge{node: node.Elem().Interface(), cursor: cursor})
+		}
+		return newConnection(edges, info, func() (int, error) {
+			count, err := binding.collection.CountDocuments(ctx, filter)
+			return int(count), err
+		}), nil
+	}
+}
@@ -0,0 +1,229 @@
+package gographql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/sssmack/gographql/internal/bsonvalue"
+	"github.com/sssmack/gographql/memcollection"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// serverPage is the page of a serversConnection query.
+type serverPage struct {
+	Names       []string
+	StartCursor string
+	EndCursor   string
+	HasNext     bool
+	HasPrevious bool
+	TotalCount  int
+}
+
+// page runs a serversConnection query having the arguments.
+func page(t *testing.T, schema graphql.Schema, args string) (page serverPage) {
+	t.Helper()
+	request := fmt.Sprintf(`{serversConnection(%v) {totalCount edges {cursor node {Name}} pageInfo {hasNextPage hasPreviousPage startCursor endCursor}}}`, args)
+	var data struct {
+		ServersConnection struct {
+			TotalCount int
+			Edges      []struct {
+				Cursor string
+				Node   struct{ Name string }
+			}
+			PageInfo struct {
+				HasNextPage, HasPreviousPage bool
+				StartCursor, EndCursor       *string
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(execute(t, schema, request, nil)), &data); nil != err {
+		t.Fatal(err)
+	}
+	connection := data.ServersConnection
+	page = serverPage{
+		Names:       []string{},
+		HasNext:     connection.PageInfo.HasNextPage,
+		HasPrevious: connection.PageInfo.HasPreviousPage,
+		TotalCount:  connection.TotalCount,
+	}
+	for _, edge := range connection.Edges {
+		page.Names = append(page.Names, edge.Node.Name)
+	}
+	if nil != connection.PageInfo.StartCursor {
+		page.StartCursor, page.EndCursor = *connection.PageInfo.StartCursor, *connection.PageInfo.EndCursor
+		if connection.Edges[0].Cursor != page.StartCursor || connection.Edges[len(connection.Edges)-1].Cursor != page.EndCursor {
+			t.Errorf("%v: the start and end cursors are not those of the edges", args)
+		}
+	}
+	return
+}
+
+// rankedServers returns documents having ranks that tie, and ranks that are null.
+func rankedServers(t *testing.T) []interface{} {
+	rank := func(rank int) *int { return &rank }
+	documents := servers(t)
+	for i, r := range []*int{rank(2), nil, rank(1), rank(2)} {
+		server := documents[i].(Server)
+		server.Rank = r
+		documents[i] = server
+	}
+	return documents
+}
+
+// pageAll pages through the connection by first and after, or by last and before, and returns the names in the order of the pages.
+func pageAll(t *testing.T, schema graphql.Schema, args string, size int, forward bool) (names []string) {
+	cursor := ""
+	for i := 0; i < 10; i++ {
+		pageArgs := fmt.Sprintf("%v, first: %v", args, size)
+		if !forward {
+			pageArgs = fmt.Sprintf("%v, last: %v", args, size)
+		}
+		if "" != cursor && forward {
+			pageArgs += fmt.Sprintf(`, after: "%v"`, cursor)
+		} else if "" != cursor {
+			pageArgs += fmt.Sprintf(`, before: "%v"`, cursor)
+		}
+		result := page(t, schema, pageArgs)
+		if forward {
+			names = append(names, result.Names...)
+			if !result.HasNext {
+				return
+			}
+			cursor = result.EndCursor
+		} else {
+			names = append(result.Names, names...)
+			if !result.HasPrevious {
+				return
+			}
+			cursor = result.StartCursor
+		}
+	}
+	t.Fatalf("%v: the pages do not end", args)
+	return
+}
+
+func TestKeysetNullSortValues(t *testing.T) {
+	_, schema := collectionSchema(t, rankedServers(t)...)
+	for _, test := range []struct {
+		args     string
+		expected []string
+	}{
+		{`sort: ["Rank"]`, []string{"b", "c", "a", "d"}},
+		{`sort: ["-Rank"]`, []string{"a", "d", "c", "b"}},
+		{`sort: ["Rank", "-Name"]`, []string{"b", "c", "d", "a"}},
+		{`sort: ["-Rank", "-Name"]`, []string{"d", "a", "c", "b"}},
+	} {
+		for _, size := range []int{1, 2, 3} {
+			for _, forward := range []bool{true, false} {
+				if names := pageAll(t, schema, test.args, size, forward); !reflect.DeepEqual(test.expected, names) {
+					t.Errorf("%v, size %v, forward %v: got %v; expected %v", test.args, size, forward, names, test.expected)
+				}
+			}
+		}
+	}
+}
+
+func TestKeysetPaging(t *testing.T) {
+	_, schema := collectionSchema(t, servers(t)...)
+	// Cpus 4 ties for b and d, and _id orders them.
+	first := page(t, schema, `sort: ["-Cpus"], first: 2`)
+	expected := serverPage{Names: []string{"b", "d"}, StartCursor: first.StartCursor, EndCursor: first.EndCursor, HasNext: true, TotalCount: 4}
+	if !reflect.DeepEqual(expected, first) || "" == first.EndCursor {
+		t.Errorf("got %+v; expected %+v", first, expected)
+	}
+	second := page(t, schema, fmt.Sprintf(`sort: ["-Cpus"], first: 2, after: "%v"`, first.EndCursor))
+	if expected := []string{"a", "c"}; !reflect.DeepEqual(expected, second.Names) || second.HasNext || !second.HasPrevious {
+		t.Errorf("after: got %+v; expected %v, and no next page", second, expected)
+	}
+	between := page(t, schema, fmt.Sprintf(`sort: ["-Cpus"], after: "%v", before: "%v"`, first.StartCursor, second.EndCursor))
+	if expected := []string{"d", "a"}; !reflect.DeepEqual(expected, between.Names) || !between.HasNext || !between.HasPrevious {
+		t.Errorf("after and before: got %+v; expected %v, and next and previous pages", between, expected)
+	}
+	last := page(t, schema, `sort: ["-Cpus"], last: 3`)
+	if expected := []string{"d", "a", "c"}; !reflect.DeepEqual(expected, last.Names) || last.HasNext || !last.HasPrevious {
+		t.Errorf("last: got %+v; expected %v, and a previous page", last, expected)
+	}
+	before := page(t, schema, fmt.Sprintf(`sort: ["-Cpus"], last: 3, before: "%v"`, last.StartCursor))
+	if expected := []string{"b"}; !reflect.DeepEqual(expected, before.Names) || !before.HasNext || before.HasPrevious {
+		t.Errorf("before: got %+v; expected %v, and a next page", before, expected)
+	}
+	firstLast := page(t, schema, `sort: ["-Cpus"], first: 3, last: 2`)
+	if expected := []string{"d", "a"}; !reflect.DeepEqual(expected, firstLast.Names) || !firstLast.HasNext || !firstLast.HasPrevious {
+		t.Errorf("first and last: got %+v; expected %v, and next and previous pages", firstLast, expected)
+	}
+	filtered := page(t, schema, `where: {Cpus: {lt: 4}}, orderBy: [{field: Name, direction: DESC}], first: 1`)
+	if expected := []string{"c"}; !reflect.DeepEqual(expected, filtered.Names) || !filtered.HasNext || 2 != filtered.TotalCount {
+		t.Errorf("where: got %+v; expected %v of 2", filtered, expected)
+	}
+	if empty := page(t, schema, `first: 0`); 0 != len(empty.Names) || !empty.HasNext || "" != empty.EndCursor {
+		t.Errorf("first 0: got %+v; expected no edges and a next page", empty)
+	}
+	for args, expected := range map[string]string{
+		`first: -1`:    "first cannot be negative",
+		`last: -1`:     "last cannot be negative",
+		`after: "!!"`:  "is not a valid cursor",
+		`before: "AA"`: "is not a valid cursor",
+		fmt.Sprintf(`sort: ["Cpus"], after: "%v"`, first.EndCursor): "is of another order",
+	} {
+		message := executeError(t, schema, fmt.Sprintf(`{serversConnection(%v) {totalCount}}`, args), nil)
+		if !strings.Contains(message, expected) {
+			t.Errorf("%v: got %q; expected %q", args, message, expected)
+		}
+	}
+}
+
+func TestKeysetCursor(t *testing.T) {
+	sort := bson.D{{Key: "rank", Value: -1}, {Key: "_id", Value: 1}}
+	id := objectID(t, "000000000000000000000001")
+	for _, document := range []bson.D{
+		{{Key: "_id", Value: id}, {Key: "rank", Value: int32(3)}},
+		{{Key: "_id", Value: id}, {Key: "rank", Value: nil}},
+		{{Key: "_id", Value: id}},
+	} {
+		cursor, err := encodeKeysetCursor(sort, document)
+		if nil != err {
+			t.Fatal(err)
+		}
+		values, err := decodeKeysetCursor(sort, cursor)
+		if nil != err {
+			t.Fatal(err)
+		}
+		rank, _ := bsonvalue.LookupFirst(document, "rank")
+		if expected := []interface{}{rank, id}; !reflect.DeepEqual(expected, values) {
+			t.Errorf("%v: got %v; expected %v", document, values, expected)
+		}
+		if _, err = decodeKeysetCursor(reverseSort(sort), cursor); nil == err {
+			t.Errorf("%v: expected the cursor to be of another order", document)
+		}
+	}
+}
+
+func TestKeysetFilter(t *testing.T) {
+	sort := bson.D{{Key: "rank", Value: 1}, {Key: "name", Value: -1}, {Key: "_id", Value: 1}}
+	id := objectID(t, "000000000000000000000001")
+	filter := keysetFilter(sort, []interface{}{nil, "b", id})
+	expected := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "rank", Value: bson.D{{Key: "$ne", Value: nil}}}},
+		bson.D{{Key: "rank", Value: nil}, {Key: "$or", Value: bson.A{
+			bson.D{{Key: "name", Value: bson.D{{Key: "$lt", Value: "b"}}}},
+			bson.D{{Key: "name", Value: nil}},
+		}}},
+		bson.D{{Key: "rank", Value: nil}, {Key: "name", Value: "b"}, {Key: "_id", Value: bson.D{{Key: "$gt", Value: id}}}},
+	}}}
+	if !reflect.DeepEqual(expected, filter) {
+		t.Errorf("got %v; expected %v", filter, expected)
+	}
+	filter = keysetFilter(bson.D{{Key: "rank", Value: -1}}, []interface{}{nil})
+	collection, err := memcollection.New(bson.D{{Key: "rank", Value: 1}}, bson.D{})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if count, err := collection.CountDocuments(context.Background(), filter); nil != err || 0 != count {
+		t.Errorf("got %v, %v; expected no document to follow a null value descending", count, err)
+	}
+}
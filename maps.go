@@ -0,0 +1,205 @@
+package gographql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	mapEntryKey   = "key"
+	mapEntryValue = "value"
+)
+
+// goMapToGraphqlType translates a Go map type to a list of key/value entries.
+// Maps having interface values, such as map[string]interface{}, are translated to the JSON scalar, unless the
+// values are replaced by replaceTypeWith.  Tags of the map field, for example replaceTypeWith, apply to the map's values.
+func (tm *typeMapper) goMapToGraphqlType(mapType reflect.Type, structField reflect.StructField, structName string) (output graphql.Type, err error) {
+	if !mapToEntries(mapType, tm.substituted(structField)) {
+		output = JSON
+		return
+	}
+	keyType := tm.registeredScalar(mapType.Key())
+	if nil == keyType {
+		switch mapType.Key().Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			err = fmt.Errorf("cannot translate map keys of kind %v", mapType.Key().Kind())
+			return
+		}
+		if keyType, err = tm.kindToGraphqlScalar(mapType.Key().Kind(), structField.Name); nil != err {
+			return
+		}
+	}
+	valueField := structField
+	valueField.Type = mapType.Elem()
+	valueType, err := tm.goFieldToGraphqlType(valueField, structName)
+	if nil != err {
+		return
+	}
+	valueType = wrapNonNull(valueType, tm.nonNull(mapType.Elem(), structField.Tag.Get(RequiredElementsTag)))
+	entryName := keyType.Name() + typeNameOf(valueType) + "Entry"
+	if graphqlInput == tm.targetType {
+		entryName = entryName + "_Input"
+	}
+	entry, defined := tm.graphqlTypes[entryName]
+	if !defined {
+		switch tm.targetType {
+		case graphqlOutput:
+			entry = graphql.NewObject(graphql.ObjectConfig{
+				Name: entryName,
+				Fields: graphql.Fields{
+					mapEntryKey:   &graphql.Field{Name: mapEntryKey, Type: graphql.NewNonNull(keyType)},
+					mapEntryValue: &graphql.Field{Name: mapEntryValue, Type: valueType},
+				},
+			})
+		case graphqlInput:
+			entry = graphql.NewInputObject(graphql.InputObjectConfig{
+				Name: entryName,
+				Fields: graphql.InputObjectConfigFieldMap{
+					mapEntryKey:   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(keyType)},
+					mapEntryValue: &graphql.InputObjectFieldConfig{Type: valueType},
+				},
+			})
+		}
+		tm.graphqlTypes[entryName] = entry
+		log.Infof("%vdeclared map entry %v", tm.indent(), entryName)
+	}
+	output = graphql.NewList(entry)
+	return
+}
+
+// substituted reports whether the replaceTypeWith tag of the field names a type that the TypeReplacer finds.
+func (tm *typeMapper) substituted(structField reflect.StructField) bool {
+	return nil != tm.typeReplacer.GetType(structField.Tag.Get(ReplaceTypeWith))
+}
+
+// mapToEntries reports whether values of the map type are translated to lists of entries, rather than to JSON.
+// goMapToGraphqlType and mapEntries decide the same way.
+func mapToEntries(mapType reflect.Type, substituted bool) bool {
+	return reflect.Interface != mapType.Elem().Kind() || substituted
+}
+
+// isMapType reports whether Type is a map, or a pointer to one.
+func isMapType(Type reflect.Type) bool {
+	if reflect.Ptr == Type.Kind() {
+		Type = Type.Elem()
+	}
+	return reflect.Map == Type.Kind()
+}
+
+// typeNameOf returns a name for Type that includes the wrapping types, for example "ListString" for [String].
+// The stub of a type that is nested in itself is named the same as the type.
+func typeNameOf(Type graphql.Type) string {
+	switch Type := Type.(type) {
+	case *graphql.List:
+		return "List" + typeNameOf(Type.OfType)
+	case *graphql.NonNull:
+		return "NonNull" + typeNameOf(Type.OfType)
+	}
+	if words := reStub.FindStringSubmatch(Type.Name()); nil != words {
+		return words[1]
+	}
+	return Type.Name()
+}
+
+// resolveMapEntries returns a resolver that converts the map that resolve returns to a list of entries,
+// ordered by key.  Other values are returned as they are.  substituted is whether the map's values are replaced by
+// replaceTypeWith; see mapToEntries.
+func resolveMapEntries(resolve graphql.FieldResolveFn, substituted bool) graphql.FieldResolveFn {
+	if nil == resolve {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		if result, err = resolve(p); nil != err {
+			return
+		}
+		return mapEntries(reflect.ValueOf(result), substituted), nil
+	}
+}
+
+func mapEntries(value reflect.Value, substituted bool) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	if reflect.Ptr == value.Kind() && !value.IsNil() {
+		value = value.Elem()
+	}
+	if reflect.Map != value.Kind() || !mapToEntries(value.Type(), substituted) {
+		return value.Interface()
+	}
+	if value.IsNil() {
+		return nil
+	}
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i], keys[j])
+	})
+	entries := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, map[string]interface{}{
+			mapEntryKey:   key.Interface(),
+			mapEntryValue: mapEntries(value.MapIndex(key), substituted),
+		})
+	}
+	return entries
+}
+
+// lessKey reports whether map key a sorts before map key b.
+func lessKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+// decodeMap sets the map target from a list of key/value entries, or from a JSON object.
+func (tm *typeMapper) decodeMap(path string, value interface{}, target reflect.Value, substitute reflect.Type) (err error) {
+	mapType := target.Type()
+	decoded := reflect.MakeMap(mapType)
+	setEntry := func(entryPath string, key, value interface{}) (err error) {
+		mapKey := reflect.New(mapType.Key()).Elem()
+		if err = tm.decodeValue(entryPath+"."+mapEntryKey, key, mapKey, nil); nil != err {
+			return
+		}
+		mapValue := reflect.New(mapType.Elem()).Elem()
+		if err = tm.decodeValue(entryPath+"."+mapEntryValue, value, mapValue, substitute); nil != err {
+			return
+		}
+		decoded.SetMapIndex(mapKey, mapValue)
+		return
+	}
+	switch value := value.(type) {
+	case []interface{}:
+		for i, element := range value {
+			entry, ok := element.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%v[%v]: cannot decode %T into a map entry", path, i, element)
+			}
+			if err = setEntry(fmt.Sprintf("%v[%v]", path, i), entry[mapEntryKey], entry[mapEntryValue]); nil != err {
+				return
+			}
+		}
+	case map[string]interface{}:
+		for key, element := range value {
+			if err = setEntry(joinPath(path, key), key, element); nil != err {
+				return
+			}
+		}
+	default:
+		return fmt.Errorf("%v: cannot decode %T into %v", path, value, mapType)
+	}
+	target.Set(decoded)
+	return
+}
@@ -0,0 +1,70 @@
+package gographql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Tree struct {
+	Name     string
+	Children map[string]Tree
+}
+
+type Measure interface {
+	Unit() string
+}
+
+type Meter struct {
+	Value float64
+}
+
+func (m Meter) Unit() string { return "m" }
+
+type Survey struct {
+	Measures map[string]Measure `replaceTypeWith:"Meter"`
+	Extra    map[string]interface{}
+}
+
+type replacer map[string]reflect.Type
+
+func (r replacer) GetType(typeName string) *reflect.Type {
+	if Type, ok := r[typeName]; ok {
+		return &Type
+	}
+	return nil
+}
+
+func TestMapOfRecursiveValues(t *testing.T) {
+	tm := NewTypeMapper()
+	tree := Tree{Name: "root", Children: map[string]Tree{"b": {Name: "leaf"}, "a": {Name: "twig"}}}
+	schema, err := tm.NewSchemaBuilder().Query(tree).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	for name := range schema.TypeMap() {
+		if strings.Contains(name, "Stub") {
+			t.Errorf("the schema has the type %v", name)
+		}
+	}
+	request := `{Name Children {key value {Name Children {key}}}}`
+	expected := `{"Children":[{"key":"a","value":{"Children":null,"Name":"twig"}},{"key":"b","value":{"Children":null,"Name":"leaf"}}],"Name":"root"}`
+	if data := execute(t, schema, request, nil); expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+}
+
+func TestMapOfReplacedInterfaces(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.SetTypeReplacer(replacer{"Meter": reflect.TypeOf(Meter{})})
+	survey := Survey{Measures: map[string]Measure{"height": Meter{Value: 2}}, Extra: map[string]interface{}{"a": 1}}
+	schema, err := tm.NewSchemaBuilder().Query(survey).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	request := `{Measures {key value {Value}} Extra}`
+	expected := `{"Extra":{"a":1},"Measures":[{"key":"height","value":{"Value":2}}]}`
+	if data := execute(t, schema, request, nil); expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+}
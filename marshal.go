@@ -0,0 +1,105 @@
+package gographql
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// A GraphQLMarshaler provides the MarshalGraphQL method.  The method returns the value that represents the receiver
+// in a graphql response; a string, number, boolean, or a map or slice of them.
+// Types that implement it, with a value or a pointer receiver, are translated to a graphql scalar named the same as the type,
+// or qualified by its package when that name is taken.
+type GraphQLMarshaler interface {
+	MarshalGraphQL() (interface{}, error)
+}
+
+// A GraphQLUnmarshaler provides the UnmarshalGraphQL method.  The method sets the receiver from the value of a graphql
+// variable or literal; a string, number, boolean, or a map or slice of them.
+type GraphQLUnmarshaler interface {
+	UnmarshalGraphQL(value interface{}) error
+}
+
+var (
+	graphqlMarshalerType = reflect.TypeOf((*GraphQLMarshaler)(nil)).Elem()
+	textMarshalerType    = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// marshals reports whether Type is named and implements GraphQLMarshaler or encoding.TextMarshaler, with a value or a pointer receiver.
+func marshals(Type reflect.Type) bool {
+	if "" == Type.Name() || reflect.Interface == Type.Kind() {
+		return false
+	}
+	pointerType := reflect.PtrTo(Type)
+	return pointerType.Implements(graphqlMarshalerType) || pointerType.Implements(textMarshalerType)
+}
+
+// marshalerScalar returns a scalar, named name, for Type when Type is named and implements GraphQLMarshaler or
+// encoding.TextMarshaler, with a value or a pointer receiver; nil when it does not.
+// The scalar parses values with UnmarshalGraphQL or UnmarshalText, when Type implements them.
+func marshalerScalar(Type reflect.Type, name string) (scalar *graphql.Scalar) {
+	if !marshals(Type) {
+		return
+	}
+	parseValue := func(value interface{}) interface{} {
+		target := reflect.New(Type)
+		switch unmarshaler := target.Interface().(type) {
+		case GraphQLUnmarshaler:
+			if err := unmarshaler.UnmarshalGraphQL(value); nil != err {
+				log.Errorf("cannot unmarshal %v; %v", Type, err)
+				return nil
+			}
+		case encoding.TextUnmarshaler:
+			text, ok := value.(string)
+			if !ok {
+				return nil
+			}
+			if err := unmarshaler.UnmarshalText([]byte(text)); nil != err {
+				log.Errorf("cannot unmarshal %v; %v", Type, err)
+				return nil
+			}
+		default:
+			return nil
+		}
+		return target.Elem().Interface()
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        name,
+		Description: fmt.Sprintf("The Go type %v.", Type),
+		Serialize: func(value interface{}) interface{} {
+			source := reflect.ValueOf(value)
+			if !source.IsValid() || reflect.Ptr == source.Kind() && source.IsNil() {
+				return nil
+			}
+			if reflect.Ptr != source.Kind() {
+				addressable := reflect.New(source.Type())
+				addressable.Elem().Set(source)
+				source = addressable
+			}
+			switch marshaler := source.Interface().(type) {
+			case GraphQLMarshaler:
+				marshaled, err := marshaler.MarshalGraphQL()
+				if nil != err {
+					log.Errorf("cannot marshal %v; %v", Type, err)
+					return nil
+				}
+				return marshaled
+			case encoding.TextMarshaler:
+				text, err := marshaler.MarshalText()
+				if nil != err {
+					log.Errorf("cannot marshal %v; %v", Type, err)
+					return nil
+				}
+				return string(text)
+			}
+			return nil
+		},
+		ParseValue: parseValue,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			return parseValue(jsonFromAST(valueAST))
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package gographql
+
+import (
+	"testing"
+	"time"
+)
+
+// ID is named the same as the built-in scalar.
+type ID string
+
+func (id ID) MarshalText() ([]byte, error) { return []byte("id-" + string(id)), nil }
+
+// Int is named the same as the built-in scalar.
+type Int int
+
+func (i Int) MarshalText() ([]byte, error) { return []byte(time.Duration(i).String()), nil }
+
+type Job struct {
+	ID      ID
+	Timeout Int
+	Elapsed time.Duration
+}
+
+func TestMarshalerScalarNames(t *testing.T) {
+	tm := NewTypeMapper()
+	schema, err := tm.NewSchemaBuilder().Query(Job{ID: "a", Timeout: Int(time.Second), Elapsed: time.Minute}).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"gographql_ID", "gographql_Int", "Duration"} {
+		if nil == schema.Type(name) {
+			t.Errorf("expected the schema to have the scalar %v", name)
+		}
+	}
+	if data, expected := execute(t, schema, `{ID Timeout Elapsed}`, nil), `{"Elapsed":"PT1M","ID":"id-a","Timeout":"1s"}`; expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+}
@@ -0,0 +1,360 @@
+// Package memcollection provides a gographql.Collection that holds its documents in memory, to stand in for Mongo in tests.
+package memcollection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sssmack/gographql/internal/bsonvalue"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection is a gographql.Collection that holds its documents in memory; a fake of a Mongo collection for tests.
+// Its filters may have field paths, which lead into the elements of arrays, the comparison operators $eq, $ne, $gt, $gte,
+// $lt, $lte, $in and $nin, the operators $exists, $regex, $size and $not, and the logical operators $and, $or and $nor.
+// Values of different BSON types are ordered the way Mongo orders them.
+type Collection struct {
+	mutex     sync.RWMutex
+	documents []bson.D
+}
+
+// New creates a collection holding the documents.  See Insert.
+func New(documents ...interface{}) (mc *Collection, err error) {
+	mc = &Collection{}
+	if err = mc.Insert(documents...); nil != err {
+		return nil, err
+	}
+	return
+}
+
+// Insert adds the documents, which are anything that bson marshals to a document.  A document that has no _id is given
+// a new ObjectID.
+func (mc *Collection) Insert(documents ...interface{}) (err error) {
+	inserted := make([]bson.D, 0, len(documents))
+	for _, document := range documents {
+		normalized, err := bsonvalue.Normalize(document)
+		if nil != err {
+			return err
+		}
+		if _, exists := bsonvalue.LookupFirst(normalized, "_id"); !exists {
+			normalized = append(bson.D{{Key: "_id", Value: primitive.NewObjectID()}}, normalized...)
+		}
+		inserted = append(inserted, normalized)
+	}
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	mc.documents = append(mc.documents, inserted...)
+	return
+}
+
+// FindOne decodes the first document that matches the filter into result.
+func (mc *Collection) FindOne(ctx context.Context, filter interface{}, result interface{}) (err error) {
+	documents, err := mc.match(filter)
+	if nil != err {
+		return
+	}
+	if 0 == len(documents) {
+		return mongo.ErrNoDocuments
+	}
+	return bsonvalue.Decode(documents[0], result)
+}
+
+// Find decodes the documents that match the filter, in the order of the sort document of opts and in the range of
+// its skip and limit, into the slice that results points to.
+func (mc *Collection) Find(ctx context.Context, filter interface{}, opts *options.FindOptions, results interface{}) (err error) {
+	target := reflect.ValueOf(results)
+	if reflect.Ptr != target.Kind() || target.IsNil() || reflect.Slice != target.Elem().Kind() {
+		return fmt.Errorf("results must be a non-nil pointer to a slice; it is %T", results)
+	}
+	documents, err := mc.match(filter)
+	if nil != err {
+		return
+	}
+	if nil != opts {
+		if nil != opts.Sort {
+			if err = sortDocuments(documents, opts.Sort); nil != err {
+				return
+			}
+		}
+		if nil != opts.Skip {
+			skip := int(*opts.Skip)
+			if skip > len(documents) {
+				skip = len(documents)
+			}
+			documents = documents[skip:]
+		}
+		if nil != opts.Limit && 0 != *opts.Limit {
+			limit := int(*opts.Limit)
+			if limit < 0 {
+				limit = -limit
+			}
+			if limit < len(documents) {
+				documents = documents[:limit]
+			}
+		}
+	}
+	elements := reflect.MakeSlice(target.Elem().Type(), 0, len(documents))
+	for _, document := range documents {
+		element := reflect.New(target.Elem().Type().Elem())
+		if err = bsonvalue.Decode(document, element.Interface()); nil != err {
+			return
+		}
+		elements = reflect.Append(elements, element.Elem())
+	}
+	target.Elem().Set(elements)
+	return
+}
+
+// CountDocuments returns the number of documents that match the filter.
+func (mc *Collection) CountDocuments(ctx context.Context, filter interface{}) (count int64, err error) {
+	documents, err := mc.match(filter)
+	return int64(len(documents)), err
+}
+
+// match returns the documents that match the filter, in the order that they were inserted.
+func (mc *Collection) match(filter interface{}) (matched []bson.D, err error) {
+	normalized := bson.D{}
+	if nil != filter {
+		if normalized, err = bsonvalue.Normalize(filter); nil != err {
+			return
+		}
+	}
+	mc.mutex.RLock()
+	defer mc.mutex.RUnlock()
+	for _, document := range mc.documents {
+		ok, err := matchFilter(document, normalized)
+		if nil != err {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, document)
+		}
+	}
+	return
+}
+
+func matchFilter(document, filter bson.D) (matched bool, err error) {
+	for _, element := range filter {
+		switch element.Key {
+		case "$and", "$or", "$nor":
+			filters, ok := element.Value.(bson.A)
+			if !ok || 0 == len(filters) {
+				return false, fmt.Errorf("%v takes a non-empty array of filters", element.Key)
+			}
+			count := 0
+			for _, value := range filters {
+				elementFilter, ok := bsonvalue.AsDocument(value)
+				if !ok {
+					return false, fmt.Errorf("%v takes a non-empty array of filters", element.Key)
+				}
+				ok, err := matchFilter(document, elementFilter)
+				if nil != err {
+					return false, err
+				}
+				if ok {
+					count++
+				}
+			}
+			switch element.Key {
+			case "$and":
+				matched = count == len(filters)
+			case "$or":
+				matched = 0 < count
+			case "$nor":
+				matched = 0 == count
+			}
+		default:
+			if strings.HasPrefix(element.Key, "$") {
+				return false, fmt.Errorf("memcollection does not support %v", element.Key)
+			}
+			values, exists := bsonvalue.LookupPath(document, element.Key)
+			if matched, err = matchCondition(values, exists, element.Value); nil != err {
+				return
+			}
+		}
+		if !matched {
+			return
+		}
+	}
+	return true, nil
+}
+
+// matchCondition returns whether one of the values of a field matches the condition; a value, or a document of operators.
+func matchCondition(values []interface{}, exists bool, condition interface{}) (matched bool, err error) {
+	if regex, ok := condition.(primitive.Regex); ok {
+		return matchRegex(values, regex.Pattern, regex.Options)
+	}
+	operators, ok := bsonvalue.AsDocument(condition)
+	if !ok || 0 == len(operators) || !strings.HasPrefix(operators[0].Key, "$") {
+		return matchEqual(values, exists, condition), nil
+	}
+	for _, operator := range operators {
+		if matched, err = matchOperator(values, exists, operator, operators); nil != err || !matched {
+			return
+		}
+	}
+	return true, nil
+}
+
+func matchOperator(values []interface{}, exists bool, operator bson.E, operators bson.D) (matched bool, err error) {
+	switch operator.Key {
+	case "$eq":
+		return matchEqual(values, exists, operator.Value), nil
+	case "$ne":
+		return !matchEqual(values, exists, operator.Value), nil
+	case "$gt", "$gte", "$lt", "$lte":
+		for _, value := range values {
+			if bsonvalue.TypeOrder(value) != bsonvalue.TypeOrder(operator.Value) {
+				continue
+			}
+			order := bsonvalue.Compare(value, operator.Value)
+			switch operator.Key {
+			case "$gt":
+				matched = 0 < order
+			case "$gte":
+				matched = 0 <= order
+			case "$lt":
+				matched = 0 > order
+			case "$lte":
+				matched = 0 >= order
+			}
+			if matched {
+				return
+			}
+		}
+		return
+	case "$in", "$nin":
+		list, ok := operator.Value.(bson.A)
+		if !ok {
+			return false, fmt.Errorf("%v takes an array", operator.Key)
+		}
+		for _, element := range list {
+			if regex, ok := element.(primitive.Regex); ok {
+				if matched, err = matchRegex(values, regex.Pattern, regex.Options); nil != err {
+					return
+				}
+			} else {
+				matched = matchEqual(values, exists, element)
+			}
+			if matched {
+				break
+			}
+		}
+		return matched == ("$in" == operator.Key), nil
+	case "$exists":
+		return truthy(operator.Value) == exists, nil
+	case "$regex":
+		var pattern, flags string
+		switch regex := operator.Value.(type) {
+		case string:
+			pattern = regex
+		case primitive.Regex:
+			pattern, flags = regex.Pattern, regex.Options
+		default:
+			return false, errors.New("$regex takes a string or a regular expression")
+		}
+		if options, exists := bsonvalue.LookupFirst(operators, "$options"); exists {
+			flags, _ = options.(string)
+		}
+		return matchRegex(values, pattern, flags)
+	case "$options":
+		return true, nil
+	case "$size":
+		for _, value := range values {
+			if array, ok := value.(bson.A); ok && 0 == bsonvalue.Compare(int64(len(array)), operator.Value) {
+				return true, nil
+			}
+		}
+		return
+	case "$not":
+		matched, err = matchCondition(values, exists, operator.Value)
+		return !matched, err
+	}
+	return false, fmt.Errorf("memcollection does not support %v", operator.Key)
+}
+
+func matchEqual(values []interface{}, exists bool, operand interface{}) bool {
+	if nil == operand && !exists {
+		return true
+	}
+	for _, value := range values {
+		if bsonvalue.TypeOrder(value) == bsonvalue.TypeOrder(operand) && 0 == bsonvalue.Compare(value, operand) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchRegex(values []interface{}, pattern, options string) (matched bool, err error) {
+	flags := ""
+	for _, option := range options {
+		switch option {
+		case 'i', 'm', 's':
+			flags += string(option)
+		default:
+			return false, fmt.Errorf("memcollection does not support the regular expression option %q", option)
+		}
+	}
+	if "" != flags {
+		pattern = "(?" + flags + ")" + pattern
+	}
+	compiled, err := regexp.Compile(pattern)
+	if nil != err {
+		return
+	}
+	for _, value := range values {
+		if text, ok := value.(string); ok && compiled.MatchString(text) {
+			return true, nil
+		}
+	}
+	return
+}
+
+func truthy(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case int32, int64, float64:
+		return 0 != bsonvalue.Compare(value, int64(0))
+	}
+	return true
+}
+
+// sortDocuments orders the documents by the sort document; a document of field paths and 1 for ascending or -1 for descending.
+func sortDocuments(documents []bson.D, sortDocument interface{}) (err error) {
+	keys, err := bsonvalue.Normalize(sortDocument)
+	if nil != err {
+		return
+	}
+	for _, key := range keys {
+		if direction := bsonvalue.Compare(key.Value, int64(0)); 2 != bsonvalue.TypeOrder(key.Value) || 0 == direction {
+			return fmt.Errorf("the direction of %v must be 1 or -1", key.Key)
+		}
+	}
+	sort.SliceStable(documents, func(i, j int) bool {
+		for _, key := range keys {
+			valueI, _ := bsonvalue.LookupFirst(documents[i], key.Key)
+			valueJ, _ := bsonvalue.LookupFirst(documents[j], key.Key)
+			order := bsonvalue.Compare(valueI, valueJ)
+			if 0 > bsonvalue.Compare(key.Value, int64(0)) {
+				order = -order
+			}
+			if 0 != order {
+				return 0 > order
+			}
+		}
+		return false
+	})
+	return
+}
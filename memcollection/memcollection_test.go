@@ -0,0 +1,114 @@
+package memcollection_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sssmack/gographql"
+	"github.com/sssmack/gographql/memcollection"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ gographql.Collection = &memcollection.Collection{}
+
+type Host struct {
+	Name string   `bson:"_id"`
+	Cpus int      `bson:"cpus"`
+	Tags []string `bson:"tags,omitempty"`
+}
+
+func hosts(t *testing.T) *memcollection.Collection {
+	collection, err := memcollection.New(
+		Host{Name: "a", Cpus: 2, Tags: []string{"db"}},
+		Host{Name: "b", Cpus: 4},
+		Host{Name: "c", Cpus: 1, Tags: []string{"db", "web"}},
+		Host{Name: "d", Cpus: 4, Tags: []string{"web"}},
+	)
+	if nil != err {
+		t.Fatal(err)
+	}
+	return collection
+}
+
+func names(found []Host) (names []string) {
+	for _, host := range found {
+		names = append(names, host.Name)
+	}
+	return
+}
+
+func TestFilters(t *testing.T) {
+	collection := hosts(t)
+	for _, test := range []struct {
+		filter   interface{}
+		expected []string
+	}{
+		{nil, []string{"a", "b", "c", "d"}},
+		{bson.M{"cpus": 4}, []string{"b", "d"}},
+		{bson.D{{Key: "cpus", Value: bson.D{{Key: "$gt", Value: 1}, {Key: "$lte", Value: 2}}}}, []string{"a"}},
+		{bson.M{"tags": "web"}, []string{"c", "d"}},
+		{bson.M{"tags": bson.M{"$size": 2}}, []string{"c"}},
+		{bson.M{"tags": bson.M{"$exists": false}}, []string{"b"}},
+		{bson.M{"_id": bson.M{"$in": bson.A{"a", primitive.Regex{Pattern: "^D", Options: "i"}}}}, []string{"a", "d"}},
+		{bson.M{"cpus": bson.M{"$not": bson.M{"$gte": 2}}}, []string{"c"}},
+		{bson.M{"$or": bson.A{bson.M{"cpus": 1}, bson.M{"tags.0": "db"}}}, []string{"a", "c"}},
+		{bson.M{"$nor": bson.A{bson.M{"cpus": 4}}}, []string{"a", "c"}},
+	} {
+		var found []Host
+		if err := collection.Find(context.Background(), test.filter, nil, &found); nil != err {
+			t.Errorf("%v: %v", test.filter, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.expected, names(found)) {
+			t.Errorf("%v: got %v; expected %v", test.filter, names(found), test.expected)
+		}
+	}
+	var found []Host
+	if err := collection.Find(context.Background(), bson.M{"$where": "true"}, nil, &found); nil == err {
+		t.Error("expected $where to be unsupported")
+	}
+}
+
+func TestFindOptions(t *testing.T) {
+	collection := hosts(t)
+	var found []Host
+	opts := options.Find().SetSort(bson.D{{Key: "cpus", Value: -1}, {Key: "_id", Value: 1}}).SetSkip(1).SetLimit(2)
+	if err := collection.Find(context.Background(), nil, opts, &found); nil != err {
+		t.Fatal(err)
+	}
+	if expected := []string{"d", "a"}; !reflect.DeepEqual(expected, names(found)) {
+		t.Errorf("got %v; expected %v", names(found), expected)
+	}
+	if err := collection.Find(context.Background(), nil, options.Find().SetSort(bson.M{"cpus": 0}), &found); nil == err {
+		t.Error("expected a sort direction of 0 to be an error")
+	}
+}
+
+func TestFindOneAndCount(t *testing.T) {
+	collection := hosts(t)
+	var host Host
+	if err := collection.FindOne(context.Background(), bson.M{"cpus": 4}, &host); nil != err || "b" != host.Name {
+		t.Errorf("got %v, %v; expected host b", host, err)
+	}
+	if err := collection.FindOne(context.Background(), bson.M{"cpus": 8}, &host); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("got %v; expected %v", err, mongo.ErrNoDocuments)
+	}
+	if count, err := collection.CountDocuments(context.Background(), bson.M{"tags": "db"}); nil != err || 2 != count {
+		t.Errorf("got %v, %v; expected 2", count, err)
+	}
+	if err := collection.Insert(bson.M{"cpus": 8}); nil != err {
+		t.Fatal(err)
+	}
+	var document bson.M
+	if err := collection.FindOne(context.Background(), bson.M{"cpus": 8}, &document); nil != err {
+		t.Fatal(err)
+	}
+	if _, ok := document["_id"].(primitive.ObjectID); !ok {
+		t.Errorf("got the _id %v; expected an ObjectID to be given", document["_id"])
+	}
+}
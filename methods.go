@@ -0,0 +1,227 @@
+package gographql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// ignoredMethods names the methods of well known interfaces; they are not translated to fields, and neither are those
+// named by IgnoreMethods.
+var ignoredMethods = map[string]bool{
+	"String":        true,
+	"GoString":      true,
+	"Error":         true,
+	"Format":        true,
+	"MarshalJSON":   true,
+	"UnmarshalJSON": true,
+	"MarshalText":   true,
+	"UnmarshalText": true,
+	"MarshalBSON":   true,
+	"UnmarshalBSON": true,
+}
+
+// SetTranslateMethods sets whether exported methods are translated to fields of output types.
+// It is true by default; IgnoreMethods leaves out methods that are not to be exposed.
+func SetTranslateMethods(translate bool) {
+	objectMapper.SetTranslateMethods(translate)
+}
+
+// SetTranslateMethods sets whether exported methods are translated to fields of output types.
+// It is true by default; IgnoreMethods leaves out methods that are not to be exposed.
+func (tm *typeMapper) SetTranslateMethods(translate bool) {
+	tm.ignoreMethods = !translate
+}
+
+// IgnoreMethods causes the named methods not to be translated to fields.  A name is either the name of a method, for
+// example "PasswordHash", which is ignored on every type, or the name of a Go type and a method, for example "Account.PasswordHash".
+func IgnoreMethods(names ...string) {
+	objectMapper.IgnoreMethods(names...)
+}
+
+// IgnoreMethods causes the named methods not to be translated to fields.  A name is either the name of a method, for
+// example "PasswordHash", which is ignored on every type, or the name of a Go type and a method, for example "Account.PasswordHash".
+func (tm *typeMapper) IgnoreMethods(names ...string) {
+	for _, name := range names {
+		tm.ignoredMethods[name] = true
+	}
+}
+
+// goMethodsToGraphqlFields adds a field to fields for each exported method of structure that has the form
+//
+//	func (T) Name([ctx context.Context,] [args ArgsStruct]) (Result[, error])
+//
+// The args struct is translated to the field's arguments and Result is translated to the field's type.
+// Methods that do not have that form, or that are named the same as a field, are ignored.
+func (tm *typeMapper) goMethodsToGraphqlFields(structure reflect.Type, structureName string, fields graphql.Fields) {
+	ptrType := reflect.PtrTo(structure)
+	for methodNumber := 0; methodNumber < ptrType.NumMethod(); methodNumber++ {
+		method := ptrType.Method(methodNumber)
+		if ignoredMethods[method.Name] || tm.ignoredMethods[method.Name] || tm.ignoredMethods[structure.Name()+"."+method.Name] {
+			continue
+		}
+		fieldName, skip := tm.fieldName(reflect.StructField{Name: method.Name})
+		if skip {
+			continue
+		}
+		if _, exists := fields[fieldName]; exists {
+			log.Infof(`%vIgnoring method "%v.%v"; a field is named "%v"`, tm.indent(), structureName, method.Name, fieldName)
+			continue
+		}
+		field, err := tm.goMethodToGraphqlField(method, structureName)
+		if nil != err {
+			log.Infof(`%vIgnoring method "%v.%v"; reason; %v`, tm.indent(), structureName, method.Name, err)
+			continue
+		}
+		field.Name = fieldName
+		fields[fieldName] = field
+	}
+}
+
+func (tm *typeMapper) goMethodToGraphqlField(method reflect.Method, structName string) (field *graphql.Field, err error) {
+	methodType := method.Type
+	in := 1 // the receiver
+	withContext := false
+	if in < methodType.NumIn() && methodType.In(in) == contextType {
+		withContext = true
+		in++
+	}
+	var argsType reflect.Type
+	if in < methodType.NumIn() {
+		argsType = methodType.In(in)
+		if reflect.Ptr == argsType.Kind() {
+			argsType = argsType.Elem()
+		}
+		if reflect.Struct != argsType.Kind() {
+			err = fmt.Errorf("argument %v is not a struct", methodType.In(in))
+			return
+		}
+		in++
+	}
+	if in != methodType.NumIn() {
+		err = errors.New("too many arguments")
+		return
+	}
+	switch methodType.NumOut() {
+	case 1:
+	case 2:
+		if methodType.Out(1) != errorType {
+			err = errors.New("the second result is not an error")
+			return
+		}
+	default:
+		err = errors.New("must return a result, or a result and an error")
+		return
+	}
+	if methodType.Out(0) == errorType {
+		err = errors.New("the result is an error")
+		return
+	}
+
+	output, err := tm.goFieldToGraphqlType(reflect.StructField{Name: method.Name, Type: methodType.Out(0)}, structName)
+	if nil != err {
+		return
+	}
+	output = wrapNonNull(output, tm.nonNull(methodType.Out(0), ""))
+	args := graphql.FieldConfigArgument{}
+	if nil != argsType {
+		if args, err = tm.goToGraphqlArgs(argsType); nil != err {
+			return
+		}
+	}
+	resolve := tm.methodResolver(method.Name, withContext, argsType, methodType)
+	if isMapType(methodType.Out(0)) && nil == tm.registeredScalar(methodType.Out(0)) {
+		resolve = resolveMapEntries(resolve, false)
+	}
+	if tm.pointsToScalar(methodType.Out(0)) {
+		resolve = resolveDereferenced(resolve)
+	}
+	field = &graphql.Field{
+		Name:    method.Name,
+		Type:    output,
+		Args:    args,
+		Resolve: resolve,
+	}
+	return
+}
+
+// goToGraphqlArgs translates the fields of the args struct to field arguments by way of its input type.
+func (tm *typeMapper) goToGraphqlArgs(argsType reflect.Type) (args graphql.FieldConfigArgument, err error) {
+	priorTargetType := tm.targetType
+	tm.targetType = graphqlInput
+	graphqlType, err := tm.goToGraphqlType(argsType)
+	tm.targetType = priorTargetType
+	if nil != err {
+		return
+	}
+	inputObject, ok := graphqlType.(*graphql.InputObject)
+	if !ok {
+		err = fmt.Errorf("got type %T; expected type graphql.InputObject", graphqlType)
+		return
+	}
+	args = graphql.FieldConfigArgument{}
+	for name, inputField := range inputObject.Fields() {
+		args[name] = &graphql.ArgumentConfig{
+			Type:         inputField.Type,
+			DefaultValue: inputField.DefaultValue,
+			Description:  inputField.Description(),
+		}
+	}
+	return
+}
+
+// methodResolver returns a resolver that calls the named method of the source.
+func (tm *typeMapper) methodResolver(methodName string, withContext bool, argsType, methodType reflect.Type) graphql.FieldResolveFn {
+	argsIsPtr := nil != argsType && reflect.Ptr == methodType.In(methodType.NumIn()-1).Kind()
+	returnsError := 2 == methodType.NumOut()
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		source := reflect.ValueOf(p.Source)
+		if !source.IsValid() || (reflect.Ptr == source.Kind() && source.IsNil()) {
+			return
+		}
+		method := source.MethodByName(methodName)
+		if !method.IsValid() && reflect.Ptr != source.Kind() {
+			addressable := reflect.New(source.Type())
+			addressable.Elem().Set(source)
+			method = addressable.MethodByName(methodName)
+		}
+		if !method.IsValid() {
+			err = fmt.Errorf(`%v has no method named "%v"`, source.Type(), methodName)
+			return
+		}
+		in := []reflect.Value{}
+		if withContext {
+			ctx := p.Context
+			if nil == ctx {
+				ctx = context.Background()
+			}
+			in = append(in, reflect.ValueOf(ctx))
+		}
+		if nil != argsType {
+			args := reflect.New(argsType)
+			if err = tm.DecodeArgs(p.Args, args.Interface()); nil != err {
+				return
+			}
+			if argsIsPtr {
+				in = append(in, args)
+			} else {
+				in = append(in, args.Elem())
+			}
+		}
+		out := method.Call(in)
+		if returnsError && !out[1].IsNil() {
+			err = out[1].Interface().(error)
+			return
+		}
+		result = out[0].Interface()
+		return
+	}
+}
@@ -0,0 +1,58 @@
+package gographql
+
+import "testing"
+
+type Account struct {
+	Name string
+	hash string
+}
+
+func (a Account) PasswordHash() string { return a.hash }
+
+type GreetingArgs struct {
+	Greeting string
+}
+
+func (a Account) Greeting(args GreetingArgs) string { return args.Greeting + " " + a.Name }
+
+func TestMethodsAreTranslated(t *testing.T) {
+	tm := NewTypeMapper()
+	schema, err := tm.NewSchemaBuilder().Query(Account{Name: "a", hash: "h"}).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if data, expected := execute(t, schema, `{PasswordHash Greeting(Greeting: "hi")}`, nil), `{"Greeting":"hi a","PasswordHash":"h"}`; expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+}
+
+func TestIgnoredMethods(t *testing.T) {
+	for _, names := range [][]string{{"PasswordHash"}, {"Account.PasswordHash"}} {
+		tm := NewTypeMapper()
+		tm.IgnoreMethods(names...)
+		object, err := tm.GoToGraphqlOutput(Account{})
+		if nil != err {
+			t.Fatal(err)
+		}
+		if _, exists := object.Fields()["PasswordHash"]; exists {
+			t.Errorf("%v: expected PasswordHash to be ignored", names)
+		}
+		if _, exists := object.Fields()["Greeting"]; !exists {
+			t.Errorf("%v: expected Greeting to be translated", names)
+		}
+	}
+	tm := NewTypeMapper()
+	tm.IgnoreMethods("Server.PasswordHash")
+	if object, err := tm.GoToGraphqlOutput(Account{}); nil != err {
+		t.Fatal(err)
+	} else if _, exists := object.Fields()["PasswordHash"]; !exists {
+		t.Error("expected a method ignored on another type to be translated")
+	}
+	tm = NewTypeMapper()
+	tm.SetTranslateMethods(false)
+	if object, err := tm.GoToGraphqlOutput(Account{}); nil != err {
+		t.Fatal(err)
+	} else if 1 != len(object.Fields()) {
+		t.Errorf("got %v; expected only the Name field", object.Fields())
+	}
+}
@@ -0,0 +1,97 @@
+package gographql
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// GraphqlTag is the name of the key for a field tag key/value pair where the value is the name of the graphql field.
+// The value "-" leaves the field out of the graphql type.  It overrides the FieldNamer.
+var GraphqlTag = "graphql"
+
+// A FieldNamer provides the GetFieldName method.  Given a struct field, the method returns the name of its graphql field,
+// "-" to leave the field out, or "" to use the name of the struct field.
+// The struct field of a method that is translated to a field has only the method's name.
+type FieldNamer interface {
+	GetFieldName(structField reflect.StructField) string
+}
+
+type defaultFieldNamer struct {
+}
+
+func (dfn defaultFieldNamer) GetFieldName(structField reflect.StructField) string {
+	return structField.Name
+}
+
+// TagFieldNamer names fields with the value of a tag key, up to its first comma; for example the names in "json", "bson" or "mo" tags.
+// Fields that do not have the tag key are named by Fallback, or with the name of the struct field when Fallback is nil.
+type TagFieldNamer struct {
+	TagKey   string
+	Fallback FieldNamer
+}
+
+// GetFieldName returns the name in the tag, or the name from Fallback.
+func (tfn TagFieldNamer) GetFieldName(structField reflect.StructField) string {
+	if name := tagName(structField.Tag.Get(tfn.TagKey)); "" != name {
+		return name
+	}
+	if nil != tfn.Fallback {
+		return tfn.Fallback.GetFieldName(structField)
+	}
+	return structField.Name
+}
+
+// LowerCamelFieldNamer names fields with the name of the struct field having its leading upper case letters
+// made lower case; for example "Name" becomes "name", "ID" becomes "id" and "VMName" becomes "vmName".
+type LowerCamelFieldNamer struct {
+}
+
+// GetFieldName returns the lower camel case name of the struct field.
+func (lcfn LowerCamelFieldNamer) GetFieldName(structField reflect.StructField) string {
+	return lowerCamel(structField.Name)
+}
+
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		if 0 < i && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// SetFieldNamer sets the namer to use.
+func SetFieldNamer(namer FieldNamer) {
+	objectMapper.SetFieldNamer(namer)
+}
+
+// SetFieldNamer sets the namer to use.
+func (tm *typeMapper) SetFieldNamer(namer FieldNamer) {
+	tm.fieldNamer = namer
+}
+
+// fieldName returns the name of the graphql field for structField, or skip when it is left out.
+func (tm *typeMapper) fieldName(structField reflect.StructField) (name string, skip bool) {
+	name = tagName(structField.Tag.Get(GraphqlTag))
+	if "" == name {
+		name = tm.fieldNamer.GetFieldName(structField)
+	}
+	if "-" == name {
+		return "", true
+	}
+	if "" == name {
+		name = structField.Name
+	}
+	return
+}
+
+// tagName returns the part of a tag value up to the first comma.
+func tagName(tagValue string) string {
+	if i := strings.Index(tagValue, ","); 0 <= i {
+		tagValue = tagValue[:i]
+	}
+	return strings.TrimSpace(tagValue)
+}
@@ -0,0 +1,73 @@
+package gographql
+
+import (
+	"testing"
+)
+
+type Profile struct {
+	UserName string `json:"user_name"`
+	Email    string `json:",omitempty"`
+	VMName   string
+	Nick     string `json:"nick" graphql:"alias"`
+	Secret   string `graphql:"-"`
+	Internal string `json:"-"`
+}
+
+type SalutationArgs struct {
+	FirstName string `json:"first_name"`
+	Title     string `json:",omitempty"`
+}
+
+func (p Profile) Salute(args SalutationArgs) string {
+	return args.Title + " " + args.FirstName + " " + p.UserName
+}
+
+func TestFieldNamers(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.SetFieldNamer(TagFieldNamer{TagKey: "json", Fallback: LowerCamelFieldNamer{}})
+	profile := Profile{UserName: "ann", Email: "a@b", VMName: "vm1", Nick: "an", Secret: "s", Internal: "i"}
+	schema, err := tm.NewSchemaBuilder().Query(profile).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	request := `{user_name email vmName alias salute(first_name: "Ann", title: "Dr")}`
+	expected := `{"alias":"an","email":"a@b","salute":"Dr Ann ann","user_name":"ann","vmName":"vm1"}`
+	if data := execute(t, schema, request, nil); expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+	fields := schema.QueryType().Fields()
+	for _, name := range []string{"Secret", "secret", "Internal", "internal", "nick", "Nick", "UserName"} {
+		if _, exists := fields[name]; exists {
+			t.Errorf("expected no field named %v", name)
+		}
+	}
+}
+
+func TestTagFieldNamerFallback(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.SetFieldNamer(TagFieldNamer{TagKey: "json"})
+	object, err := tm.GoToGraphqlOutput(Profile{})
+	if nil != err {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"user_name", "Email", "VMName", "alias"} {
+		if _, exists := object.Fields()[name]; !exists {
+			t.Errorf("expected a field named %v; got %v", name, object.Fields())
+		}
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Name":   "name",
+		"ID":     "id",
+		"VMName": "vmName",
+		"name":   "name",
+		"":       "",
+		"X":      "x",
+	} {
+		if camel := lowerCamel(name); expected != camel {
+			t.Errorf("%v: got %v; expected %v", name, camel, expected)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package gographql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// RequiredTag is the name of the key for a field tag key/value pair where the value is "true" or "false".
+// "true" declares the graphql field NonNull and "false" declares it nullable, whatever the kind of the field.
+// Without the tag, the field is NonNull when SetNonNullByDefault is set and the field is not a pointer, interface, slice or map.
+var RequiredTag = "required"
+
+// RequiredElementsTag is the name of the key for a field tag key/value pair where the value is "true" or "false".
+// It is the same as RequiredTag for the elements of a list, or the values of a map.
+var RequiredElementsTag = "requiredElements"
+
+// SetNonNullByDefault sets whether fields, list elements and map values that are not pointers, interfaces,
+// slices or maps are declared NonNull when they are not tagged otherwise.  It applies to input and output types.
+func SetNonNullByDefault(nonNull bool) {
+	objectMapper.SetNonNullByDefault(nonNull)
+}
+
+// SetNonNullByDefault sets whether fields, list elements and map values that are not pointers, interfaces,
+// slices or maps are declared NonNull when they are not tagged otherwise.  It applies to input and output types.
+func (tm *typeMapper) SetNonNullByDefault(nonNull bool) {
+	tm.nonNullByDefault = nonNull
+}
+
+// nonNull reports whether a value of the Go Type, tagged with required, is declared NonNull.
+func (tm *typeMapper) nonNull(Type reflect.Type, required string) bool {
+	switch required {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if !tm.nonNullByDefault {
+		return false
+	}
+	switch Type.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return false
+	}
+	return true
+}
+
+// wrapNonNull returns Type declared NonNull when nonNull is true, otherwise Type.
+func wrapNonNull(Type graphql.Type, nonNull bool) graphql.Type {
+	if _, isNonNull := Type.(*graphql.NonNull); !nonNull || isNonNull {
+		return Type
+	}
+	return graphql.NewNonNull(Type)
+}
@@ -0,0 +1,72 @@
+package gographql
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Node struct {
+	Label    string
+	Weight   int      `required:"false"`
+	Tags     []string `required:"true"`
+	Parent   *Node
+	Children []Node            `required:"true" requiredElements:"true"`
+	Scores   map[string]int    `required:"true"`
+	Notes    map[string]string `requiredElements:"false"`
+}
+
+// nodeSchema returns a schema, having SetNonNullByDefault set, whose query is root.
+func nodeSchema(t *testing.T, root Node) (schema graphql.Schema) {
+	tm := NewTypeMapper()
+	tm.SetNonNullByDefault(true)
+	schema, err := tm.NewSchemaBuilder().Query(root).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestNonNullByDefault(t *testing.T) {
+	schema := nodeSchema(t, Node{})
+	node, ok := schema.Type("Node").(*graphql.Object)
+	if !ok {
+		t.Fatalf("got %v; expected the object Node", schema.Type("Node"))
+	}
+	for field, expected := range map[string]string{
+		"Label":    "String!",
+		"Weight":   "Int",
+		"Tags":     "[String!]!",
+		"Parent":   "Node",
+		"Children": "[Node!]!",
+		"Scores":   "[StringNonNullIntEntry]!",
+		"Notes":    "[StringStringEntry]",
+	} {
+		if Type := node.Fields()[field].Type.String(); expected != Type {
+			t.Errorf("%v: got %v; expected %v", field, Type, expected)
+		}
+	}
+	entry, ok := schema.Type("StringNonNullIntEntry").(*graphql.Object)
+	if !ok || "Int!" != entry.Fields()["value"].Type.String() {
+		t.Errorf("got %v; expected the entries of Scores to have Int! values", schema.Type("StringNonNullIntEntry"))
+	}
+	if notes, ok := schema.Type("StringStringEntry").(*graphql.Object); !ok || "String" != notes.Fields()["value"].Type.String() {
+		t.Errorf("got %v; expected the entries of Notes to have nullable values", schema.Type("StringStringEntry"))
+	}
+}
+
+func TestNonNullByDefaultResolves(t *testing.T) {
+	root := Node{
+		Label:    "root",
+		Tags:     []string{"a"},
+		Children: []Node{{Label: "child", Tags: []string{}, Children: []Node{}, Scores: map[string]int{}}},
+		Scores:   map[string]int{"x": 1},
+	}
+	root.Children[0].Parent = &Node{Label: "root"}
+	schema := nodeSchema(t, root)
+	request := `{Label Weight Tags Parent {Label} Children {Label Parent {Label} Tags} Scores {key value}}`
+	expected := `{"Children":[{"Label":"child","Parent":{"Label":"root"},"Tags":[]}],"Label":"root","Parent":null,"Scores":[{"key":"x","value":1}],"Tags":["a"],"Weight":0}`
+	if data := execute(t, schema, request, nil); expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+}
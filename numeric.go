@@ -0,0 +1,157 @@
+package gographql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// numericText returns the text of a number given as a string, a json.Number, a Go number, or an Int, Float or String literal.
+func numericText(value interface{}) (text string, ok bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case json.Number:
+		return value.String(), true
+	case float32:
+		return strconv.FormatFloat(float64(value), 'g', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(value), true
+	case *ast.IntValue:
+		return value.Value, true
+	case *ast.FloatValue:
+		return value.Value, true
+	case *ast.StringValue:
+		return value.Value, true
+	}
+	return "", false
+}
+
+// reDecimalNumber matches numbers written in decimal; big.Rat also parses fractions such as "4/2", and prefixes such as "0x".
+var reDecimalNumber = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// precisionOf returns the number of mantissa bits that hold the decimal digits of text without loss.
+func precisionOf(text string) uint {
+	if precision := uint(len(text)) * 4; precision > 64 {
+		return precision
+	}
+	return 64
+}
+
+func parseDecimal(value interface{}) interface{} {
+	text, ok := numericText(value)
+	if !ok {
+		return nil
+	}
+	decimal, err := primitive.ParseDecimal128(text)
+	if nil != err {
+		log.Infof("Decimal: %v", err)
+		return nil
+	}
+	return decimal
+}
+
+// Decimal reflects the bson Decimal128 to a graphql type and vice versa.  It is serialized as a string, without loss of precision,
+// and parses strings and numbers.
+var Decimal = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Decimal",
+	Description: "A 128 bit decimal.  It is serialized as a string.",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case primitive.Decimal128:
+			return value.String()
+		case *primitive.Decimal128:
+			if nil != value {
+				return value.String()
+			}
+		}
+		return nil
+	},
+	ParseValue: parseDecimal,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return parseDecimal(valueAST)
+	},
+})
+
+func parseBigInt(value interface{}) interface{} {
+	text, ok := numericText(value)
+	if !ok {
+		return nil
+	}
+	i, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		// numbers such as 1e21, and float64 variables, are integers that are not written as integers.
+		r, ok := new(big.Rat).SetString(text)
+		if !ok || !r.IsInt() || !reDecimalNumber.MatchString(text) {
+			log.Infof("BigInt: %v is not an integer", text)
+			return nil
+		}
+		i = r.Num()
+	}
+	return i
+}
+
+// BigInt reflects the Go big.Int to a graphql type and vice versa.  It is serialized as a decimal string, and parses
+// strings and integral numbers to a *big.Int.
+var BigInt = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "BigInt",
+	Description: "An integer of any size.  It is serialized as a decimal string.",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case big.Int:
+			return value.String()
+		case *big.Int:
+			if nil != value {
+				return value.String()
+			}
+		}
+		return nil
+	},
+	ParseValue: parseBigInt,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return parseBigInt(valueAST)
+	},
+})
+
+func parseBigFloat(value interface{}) interface{} {
+	text, ok := numericText(value)
+	if !ok {
+		return nil
+	}
+	f, _, err := big.ParseFloat(text, 10, precisionOf(text), big.ToNearestEven)
+	if nil != err {
+		log.Infof("BigFloat: %v", err)
+		return nil
+	}
+	return f
+}
+
+// BigFloat reflects the Go big.Float to a graphql type and vice versa.  It is serialized as a string having the
+// precision of the value, and parses strings and numbers to a *big.Float.
+var BigFloat = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "BigFloat",
+	Description: "A floating point number of any precision.  It is serialized as a string.",
+	Serialize: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case big.Float:
+			return value.Text('g', -1)
+		case *big.Float:
+			if nil != value {
+				return value.Text('g', -1)
+			}
+		}
+		return nil
+	},
+	ParseValue: parseBigFloat,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return parseBigFloat(valueAST)
+	},
+})
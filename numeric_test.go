@@ -0,0 +1,133 @@
+package gographql
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// scalarTest is a value that a scalar serializes, or parses from a variable or a literal.  expected is nil when the
+// value is invalid; otherwise it is what the scalar serializes the parsed value to.
+type scalarTest struct {
+	value    interface{}
+	expected interface{}
+}
+
+// serialize returns what the scalar serializes value to, having JSON documents as strings so that they compare.
+func serialize(scalar *graphql.Scalar, value interface{}) interface{} {
+	serialized := scalar.Serialize(value)
+	if document, ok := serialized.(json.RawMessage); ok {
+		return string(document)
+	}
+	return serialized
+}
+
+// testSerialize checks what the scalar serializes the values to.
+func testSerialize(t *testing.T, scalar *graphql.Scalar, tests []scalarTest) {
+	t.Helper()
+	for _, test := range tests {
+		if serialized := serialize(scalar, test.value); test.expected != serialized {
+			t.Errorf("%v.Serialize(%#v): got %#v; expected %#v", scalar, test.value, serialized, test.expected)
+		}
+	}
+}
+
+// testParse checks that the values, given as variables or as literals when they are ast.Values, are parsed to values
+// that the scalar serializes to expected.
+func testParse(t *testing.T, scalar *graphql.Scalar, tests []scalarTest) {
+	t.Helper()
+	for _, test := range tests {
+		var parsed interface{}
+		if literal, ok := test.value.(ast.Value); ok {
+			parsed = scalar.ParseLiteral(literal)
+		} else {
+			parsed = scalar.ParseValue(test.value)
+		}
+		if nil == test.expected {
+			if nil != parsed {
+				t.Errorf("%v: parsing %#v: got %#v; expected it to be invalid", scalar, test.value, parsed)
+			}
+			continue
+		}
+		if serialized := serialize(scalar, parsed); test.expected != serialized {
+			t.Errorf("%v: parsing %#v: got %#v, serialized as %#v; expected %#v", scalar, test.value, parsed, serialized, test.expected)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	decimal, err := primitive.ParseDecimal128("12345678901234567890.123456789")
+	if nil != err {
+		t.Fatal(err)
+	}
+	testSerialize(t, Decimal, []scalarTest{
+		{decimal, "12345678901234567890.123456789"},
+		{&decimal, "12345678901234567890.123456789"},
+		{(*primitive.Decimal128)(nil), nil},
+		{1.5, nil},
+	})
+	testParse(t, Decimal, []scalarTest{
+		{"12345678901234567890.123456789", "12345678901234567890.123456789"},
+		{json.Number("-0.001"), "-0.001"},
+		{2.5, "2.5"},
+		{int64(7), "7"},
+		{&ast.StringValue{Value: "1E+3"}, "1E+3"},
+		{&ast.FloatValue{Value: "0.1"}, "0.1"},
+		{&ast.IntValue{Value: "99999999999999999999"}, "99999999999999999999"},
+		{"ten", nil},
+		{true, nil},
+		{&ast.BooleanValue{Value: true}, nil},
+	})
+}
+
+func TestBigInt(t *testing.T) {
+	i, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	testSerialize(t, BigInt, []scalarTest{
+		{i, "-123456789012345678901234567890"},
+		{*i, "-123456789012345678901234567890"},
+		{(*big.Int)(nil), nil},
+		{7, nil},
+	})
+	testParse(t, BigInt, []scalarTest{
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+		{json.Number("42"), "42"},
+		{1e21, "1000000000000000000000"},
+		{uint64(18446744073709551615), "18446744073709551615"},
+		{&ast.IntValue{Value: "-99999999999999999999"}, "-99999999999999999999"},
+		{&ast.FloatValue{Value: "2.0"}, "2"},
+		{&ast.StringValue{Value: "3"}, "3"},
+		{2.5, nil},
+		{&ast.FloatValue{Value: "2.5"}, nil},
+		{"0x10", nil},
+		{"4/2", nil},
+		{[]int{1}, nil},
+	})
+}
+
+func TestBigFloat(t *testing.T) {
+	f, _, err := big.ParseFloat("3.14159265358979323846264338327950288", 10, 200, big.ToNearestEven)
+	if nil != err {
+		t.Fatal(err)
+	}
+	testSerialize(t, BigFloat, []scalarTest{
+		{big.NewFloat(1.5), "1.5"},
+		{*big.NewFloat(-2), "-2"},
+		{(*big.Float)(nil), nil},
+		{1.5, nil},
+	})
+	testParse(t, BigFloat, []scalarTest{
+		{f.Text('g', -1), f.Text('g', -1)},
+		{"0.1", "0.1"},
+		{json.Number("1e100"), "1e+100"},
+		{0.25, "0.25"},
+		{int8(-3), "-3"},
+		{&ast.FloatValue{Value: "3.14159265358979323846264338327950288"}, "3.14159265358979323846264338327950288"},
+		{&ast.IntValue{Value: "12345678901234567890123"}, "1.2345678901234567890123e+22"},
+		{"pi", nil},
+		{&ast.EnumValue{Value: "PI"}, nil},
+	})
+}
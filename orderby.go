@@ -0,0 +1,299 @@
+package gographql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+	"github.com/sssmack/gographql/internal/bsonvalue"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SortableTag is the name of the key for a field tag key/value pair where the value "true" gives a list field of
+// structs the orderBy argument, which orders the list by the fields of the structs.
+var SortableTag = "sortable"
+
+// SortDirection orders values ascending, ASC, or descending, DESC.
+var SortDirection = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "SortDirection",
+	Description: "The direction of an ordering.",
+	Values: graphql.EnumValueConfigMap{
+		"ASC":  &graphql.EnumValueConfig{Value: 1, Description: "Ascending."},
+		"DESC": &graphql.EnumValueConfig{Value: -1, Description: "Descending."},
+	},
+})
+
+// orderKey is a field to order by, and the direction, 1 or -1.
+type orderKey struct {
+	name      string
+	path      documentPath
+	direction int
+}
+
+// OrderByInput returns the <Type>_OrderBy input object of the Go struct, given as a value, a pointer to one, or its reflect.Type.
+// An orderBy argument is a list of them; each names a field to order by, and the direction.  See SortSlice and SortFindOptions.
+func OrderByInput(goStruct interface{}) (orderBy *graphql.InputObject, err error) {
+	return objectMapper.OrderByInput(goStruct)
+}
+
+// OrderByInput returns the <Type>_OrderBy input object of the Go struct, given as a value, a pointer to one, or its reflect.Type.
+// An orderBy argument is a list of them; each names a field to order by, a value of the <Type>_OrderByField enum, and the
+// direction, a SortDirection.  The fields are those that are sortable; of numbers, strings, booleans, enums and ordered
+// scalars such as DateTime and ObjectID.  See SortSlice and SortFindOptions.
+func (tm *typeMapper) OrderByInput(goStruct interface{}) (orderBy *graphql.InputObject, err error) {
+	Type, err := structType(goStruct)
+	if nil != err {
+		return
+	}
+	return tm.orderByInput(Type)
+}
+
+func (tm *typeMapper) orderByInput(Type reflect.Type) (orderBy *graphql.InputObject, err error) {
+	name := Type.Name() + "_OrderBy"
+	if orderBy, ok := tm.graphqlTypes[name].(*graphql.InputObject); ok {
+		return orderBy, nil
+	}
+	values := graphql.EnumValueConfigMap{}
+	for fieldName, path := range tm.structPaths(Type) {
+		if tm.sortable(path.Type) {
+			values[fieldName] = &graphql.EnumValueConfig{Value: fieldName}
+		}
+	}
+	if 0 == len(values) {
+		err = fmt.Errorf("%v has no sortable fields", Type)
+		return
+	}
+	fieldEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        Type.Name() + "_OrderByField",
+		Description: fmt.Sprintf("The fields that %v values are ordered by.", Type.Name()),
+		Values:      values,
+	})
+	orderBy = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        name,
+		Description: fmt.Sprintf("A field that %v values are ordered by, and the direction.", Type.Name()),
+		Fields: graphql.InputObjectConfigFieldMap{
+			"field":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(fieldEnum)},
+			"direction": &graphql.InputObjectFieldConfig{Type: SortDirection, DefaultValue: 1},
+		},
+	})
+	tm.graphqlTypes[fieldEnum.Name()] = fieldEnum
+	tm.graphqlTypes[name] = orderBy
+	return
+}
+
+// orderByArgs returns the orderBy argument of Type.
+func (tm *typeMapper) orderByArgs(Type reflect.Type) (args graphql.FieldConfigArgument, err error) {
+	orderBy, err := tm.orderByInput(Type)
+	if nil != err {
+		return
+	}
+	args = graphql.FieldConfigArgument{
+		"orderBy": &graphql.ArgumentConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(orderBy)),
+			Description: "The fields to order by; the first one first.",
+		},
+	}
+	return
+}
+
+// sortableArgs returns the orderBy argument of a field that is tagged sortable; the field must be a list of structs.
+func (tm *typeMapper) sortableArgs(structField reflect.StructField) (args graphql.FieldConfigArgument, err error) {
+	Type := indirectType(structField.Type)
+	if reflect.Slice != Type.Kind() && reflect.Array != Type.Kind() {
+		return nil, fmt.Errorf("it is tagged %v but is not a list", SortableTag)
+	}
+	elementType := tm.nestedStruct(Type)
+	if nil == elementType {
+		return nil, fmt.Errorf("it is tagged %v but is not a list of structs", SortableTag)
+	}
+	return tm.orderByArgs(elementType)
+}
+
+// sortable returns whether values of Type can be ordered.
+func (tm *typeMapper) sortable(Type reflect.Type) bool {
+	if _, isEnum := tm.enums[Type]; isEnum {
+		return true
+	}
+	if scalar := tm.registeredScalar(Type); nil != scalar {
+		return orderedScalars[scalar.Name()]
+	}
+	switch Type.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// orderKeys returns the keys of the value of an orderBy argument of Type.
+func (tm *typeMapper) orderKeys(Type reflect.Type, orderBy interface{}) (keys []orderKey, err error) {
+	if nil == orderBy {
+		return
+	}
+	list, ok := orderBy.([]interface{})
+	if !ok {
+		list = []interface{}{orderBy}
+	}
+	paths := tm.structPaths(Type)
+	for i, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("orderBy[%v]: got %T; expected an object", i, element)
+		}
+		name, _ := object["field"].(string)
+		path, ok := paths[name]
+		if !ok || !tm.sortable(path.Type) {
+			return nil, fmt.Errorf(`orderBy[%v]: %v has no sortable field named "%v"`, i, Type, name)
+		}
+		direction := 1
+		if value, ok := object["direction"]; ok && nil != value {
+			if direction, ok = value.(int); !ok || (1 != direction && -1 != direction) {
+				return nil, fmt.Errorf("orderBy[%v]: the direction %v is not ASC or DESC", i, value)
+			}
+		}
+		keys = append(keys, orderKey{name: name, path: path, direction: direction})
+	}
+	return
+}
+
+// OrderByToBSON translates the value of an orderBy argument, as it is given to a resolver, to a Mongo sort document,
+// using the bson tags of the Go struct, given as a value, a pointer to one, or its reflect.Type, for field paths.
+func OrderByToBSON(goStruct interface{}, orderBy interface{}) (sort bson.D, err error) {
+	return objectMapper.OrderByToBSON(goStruct, orderBy)
+}
+
+// OrderByToBSON translates the value of an orderBy argument, as it is given to a resolver, to a Mongo sort document,
+// using the bson tags of the Go struct, given as a value, a pointer to one, or its reflect.Type, for field paths.
+func (tm *typeMapper) OrderByToBSON(goStruct interface{}, orderBy interface{}) (sort bson.D, err error) {
+	Type, err := structType(goStruct)
+	if nil != err {
+		return
+	}
+	keys, err := tm.orderKeys(Type, orderBy)
+	if nil != err {
+		return
+	}
+	sort = bson.D{}
+	for _, key := range keys {
+		sort = append(sort, bson.E{Key: key.path.bson, Value: key.direction})
+	}
+	return
+}
+
+// SortFindOptions sets the sort of the find options to the value of an orderBy argument of the Go struct, given as a value,
+// a pointer to one, or its reflect.Type.
+func SortFindOptions(opts *options.FindOptions, goStruct interface{}, orderBy interface{}) (err error) {
+	return objectMapper.SortFindOptions(opts, goStruct, orderBy)
+}
+
+// SortFindOptions sets the sort of the find options to the value of an orderBy argument of the Go struct, given as a value,
+// a pointer to one, or its reflect.Type.
+func (tm *typeMapper) SortFindOptions(opts *options.FindOptions, goStruct interface{}, orderBy interface{}) (err error) {
+	sort, err := tm.OrderByToBSON(goStruct, orderBy)
+	if nil != err {
+		return
+	}
+	opts.SetSort(sort)
+	return
+}
+
+// SortSlice orders the slice of structs, or of pointers to structs, in place by the value of an orderBy argument.
+// Values are ordered the way Mongo orders them, and the sort is stable.
+func SortSlice(slice interface{}, orderBy interface{}) (err error) {
+	return objectMapper.SortSlice(slice, orderBy)
+}
+
+// SortSlice orders the slice of structs, or of pointers to structs, in place by the value of an orderBy argument.
+// Values are ordered the way Mongo orders them, and the sort is stable.
+func (tm *typeMapper) SortSlice(slice interface{}, orderBy interface{}) (err error) {
+	elements := reflect.ValueOf(slice)
+	if reflect.Slice != elements.Kind() || reflect.Struct != indirectType(elements.Type().Elem()).Kind() {
+		return fmt.Errorf("%T is not a slice of structs", slice)
+	}
+	keys, err := tm.orderKeys(indirectType(elements.Type().Elem()), orderBy)
+	if nil != err || 0 == len(keys) {
+		return
+	}
+	type sortable struct {
+		element reflect.Value
+		values  []interface{}
+	}
+	sorted := make([]sortable, elements.Len())
+	for i := range sorted {
+		sorted[i].element = reflect.ValueOf(elements.Index(i).Interface())
+		for _, key := range keys {
+			value, err := bsonValue(fieldByIndex(sorted[i].element, key.path.index))
+			if nil != err {
+				return fmt.Errorf("%v: %v", key.name, err)
+			}
+			sorted[i].values = append(sorted[i].values, value)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for k, key := range keys {
+			if order := bsonvalue.Compare(sorted[i].values[k], sorted[j].values[k]) * key.direction; 0 != order {
+				return 0 > order
+			}
+		}
+		return false
+	})
+	for i := range sorted {
+		elements.Index(i).Set(sorted[i].element)
+	}
+	return
+}
+
+// fieldByIndex returns the value of the field of the struct at index, or nil when a pointer on the way is nil.
+func fieldByIndex(structure reflect.Value, index []int) interface{} {
+	field := structure
+	for _, fieldNumber := range index {
+		for reflect.Ptr == field.Kind() {
+			if field.IsNil() {
+				return nil
+			}
+			field = field.Elem()
+		}
+		field = field.Field(fieldNumber)
+	}
+	return field.Interface()
+}
+
+// bsonValue returns value as bson decodes it, so that values compare the way Mongo compares them.
+func bsonValue(value interface{}) (decoded interface{}, err error) {
+	document, err := bsonvalue.Normalize(bson.D{{Key: "v", Value: value}})
+	if nil != err {
+		return
+	}
+	return document[0].Value, nil
+}
+
+// resolveSorted returns a resolver that orders the list that resolve returns by the orderBy argument.
+// The list is copied, so the source is not reordered.
+func (tm *typeMapper) resolveSorted(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if nil == resolve {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		result, err = resolve(p)
+		orderBy, ok := p.Args["orderBy"]
+		if nil != err || !ok || nil == orderBy {
+			return
+		}
+		list := reflect.ValueOf(result)
+		for list.IsValid() && reflect.Ptr == list.Kind() && !list.IsNil() {
+			list = list.Elem()
+		}
+		if !list.IsValid() || (reflect.Slice != list.Kind() && reflect.Array != list.Kind()) {
+			return
+		}
+		copied := reflect.MakeSlice(reflect.SliceOf(list.Type().Elem()), list.Len(), list.Len())
+		reflect.Copy(copied, list)
+		if err = tm.SortSlice(copied.Interface(), orderBy); nil != err {
+			return nil, err
+		}
+		return copied.Interface(), nil
+	}
+}
@@ -0,0 +1,111 @@
+package gographql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type Owner struct {
+	Team string
+}
+
+type Ticket struct {
+	Title    string
+	Priority int
+	Open     bool
+	*Owner
+}
+
+type Board struct {
+	Tickets []Ticket `sortable:"true"`
+}
+
+// tickets returns tickets having tied priorities, and owners that are nil.
+func tickets() []Ticket {
+	return []Ticket{
+		{Title: "a", Priority: 1, Owner: &Owner{Team: "ops"}},
+		{Title: "b", Priority: 2},
+		{Title: "c", Priority: 2, Owner: &Owner{Team: "dev"}, Open: true},
+		{Title: "d", Priority: 1},
+		{Title: "e", Priority: 2, Owner: &Owner{Team: "ops"}},
+	}
+}
+
+func titles(tickets []Ticket) (titles []string) {
+	for _, ticket := range tickets {
+		titles = append(titles, ticket.Title)
+	}
+	return
+}
+
+func TestSortSlice(t *testing.T) {
+	key := func(field string, direction int) interface{} {
+		return map[string]interface{}{"field": field, "direction": direction}
+	}
+	for _, test := range []struct {
+		orderBy  interface{}
+		expected []string
+	}{
+		{[]interface{}{key("Priority", -1), key("Team", 1)}, []string{"b", "c", "e", "d", "a"}},
+		{[]interface{}{key("Team", -1), key("Priority", 1)}, []string{"a", "e", "c", "d", "b"}},
+		{[]interface{}{key("Open", -1), map[string]interface{}{"field": "Title"}}, []string{"c", "a", "b", "d", "e"}},
+		{[]interface{}{key("Priority", 1)}, []string{"a", "d", "b", "c", "e"}},
+		{nil, []string{"a", "b", "c", "d", "e"}},
+	} {
+		sorted := tickets()
+		if err := SortSlice(sorted, test.orderBy); nil != err {
+			t.Fatal(err)
+		}
+		if names := titles(sorted); !reflect.DeepEqual(test.expected, names) {
+			t.Errorf("%v: got %v; expected %v", test.orderBy, names, test.expected)
+		}
+		pointers := []*Ticket{}
+		for _, ticket := range tickets() {
+			ticket := ticket
+			pointers = append(pointers, &ticket)
+		}
+		if err := SortSlice(pointers, test.orderBy); nil != err {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _, ticket := range pointers {
+			names = append(names, ticket.Title)
+		}
+		if !reflect.DeepEqual(test.expected, names) {
+			t.Errorf("%v, of pointers: got %v; expected %v", test.orderBy, names, test.expected)
+		}
+	}
+	for orderBy, expected := range map[string]interface{}{
+		`has no sortable field named "Owner"`: []interface{}{key("Owner", 1)},
+		"is not ASC or DESC":                  []interface{}{key("Title", 2)},
+		"expected an object":                  []interface{}{"Title"},
+	} {
+		if err := SortSlice(tickets(), expected); nil == err || !strings.Contains(err.Error(), orderBy) {
+			t.Errorf("%v: got %v; expected an error that %v", expected, err, orderBy)
+		}
+	}
+	if err := SortSlice([]int{2, 1}, nil); nil == err {
+		t.Error("expected a slice of ints not to be sorted")
+	}
+}
+
+func TestSortableField(t *testing.T) {
+	board := Board{Tickets: tickets()}
+	tm := NewTypeMapper()
+	schema, err := tm.NewSchemaBuilder().Query(board).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	request := `{Tickets(orderBy: [{field: Priority, direction: DESC}, {field: Team}]) {Title Team}}`
+	expected := `{"Tickets":[{"Team":null,"Title":"b"},{"Team":"dev","Title":"c"},{"Team":"ops","Title":"e"},{"Team":null,"Title":"d"},{"Team":"ops","Title":"a"}]}`
+	if data := execute(t, schema, request, nil); expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+	if names, expected := titles(board.Tickets), []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(expected, names) {
+		t.Errorf("got %v; expected the source to keep its order, %v", names, expected)
+	}
+	if data, expected := execute(t, schema, `{Tickets {Title}}`, nil), `{"Tickets":[{"Title":"a"},{"Title":"b"},{"Title":"c"},{"Title":"d"},{"Title":"e"}]}`; expected != data {
+		t.Errorf("without orderBy: got %v; expected %v", data, expected)
+	}
+}
@@ -0,0 +1,42 @@
+package gographql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sssmack/gographql"
+)
+
+// PageInfo is a type of an application that has the name of the page information of connections.
+type PageInfo struct {
+	Number int
+}
+
+type Catalog struct {
+	Page  PageInfo
+	Items []string `connection:"true"`
+}
+
+// Archive has the fields of Catalog in the other order, so that the connection is translated first.
+type Archive struct {
+	Items []string `connection:"true"`
+	Page  PageInfo
+}
+
+func TestPageInfoClash(t *testing.T) {
+	for _, root := range []interface{}{Catalog{}, Archive{}} {
+		tm := gographql.NewTypeMapper()
+		if _, err := tm.NewSchemaBuilder().Query(root).Build(); nil == err || !strings.Contains(err.Error(), "SetPageInfoName") {
+			t.Errorf("%T: got %v; expected an error that the name PageInfo is taken", root, err)
+		}
+	}
+	tm := gographql.NewTypeMapper()
+	tm.SetPageInfoName("CatalogPageInfo")
+	schema, err := tm.NewSchemaBuilder().Query(Catalog{Page: PageInfo{Number: 2}, Items: []string{"a", "b"}}).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if nil == schema.Type("PageInfo") || nil == schema.Type("CatalogPageInfo") {
+		t.Errorf("got %v and %v; expected both types", schema.Type("PageInfo"), schema.Type("CatalogPageInfo"))
+	}
+}
@@ -0,0 +1,174 @@
+package gographql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultScalars returns the scalars that every type mapper starts with.
+func defaultScalars() (scalars map[reflect.Type]*graphql.Scalar) {
+	scalars = map[reflect.Type]*graphql.Scalar{
+		reflect.TypeOf(primitive.ObjectID{}):   ObjectID,
+		reflect.TypeOf(time.Time{}):            graphql.DateTime,
+		reflect.TypeOf(json.RawMessage{}):      JSON,
+		reflect.TypeOf(primitive.Decimal128{}): Decimal,
+		reflect.TypeOf(big.Int{}):              BigInt,
+		reflect.TypeOf(big.Float{}):            BigFloat,
+	}
+	for _, pack := range []map[reflect.Type]*graphql.Scalar{stdScalars(), bsonScalars()} {
+		for Type, scalar := range pack {
+			scalars[Type] = scalar
+		}
+	}
+	return
+}
+
+// RegisterScalar causes values of the Go Type to be translated to the graphql scalar, wherever the Type appears;
+// as a field, a pointer, the element of a slice or array, the key or value of a map, or a replaceTypeWith substitution.
+// It replaces the scalar that is registered for the Type, for example the defaults for primitive.ObjectID and time.Time.
+func RegisterScalar(Type reflect.Type, scalar *graphql.Scalar) (err error) {
+	return objectMapper.RegisterScalar(Type, scalar)
+}
+
+// RegisterScalar causes values of the Go Type to be translated to the graphql scalar, wherever the Type appears;
+// as a field, a pointer, the element of a slice or array, the key or value of a map, or a replaceTypeWith substitution.
+// It replaces the scalar that is registered for the Type, for example the defaults for primitive.ObjectID and time.Time.
+func (tm *typeMapper) RegisterScalar(Type reflect.Type, scalar *graphql.Scalar) (err error) {
+	if nil == Type {
+		err = errors.New("cannot register a scalar for a nil type")
+		log.Error(err)
+		return
+	}
+	if nil == scalar {
+		err = fmt.Errorf("cannot register a nil scalar for %v", Type)
+		log.Error(err)
+		return
+	}
+	if err = scalar.Error(); nil != err {
+		log.Error(err)
+		return
+	}
+	if reflect.Ptr == Type.Kind() {
+		Type = Type.Elem()
+	}
+	tm.scalars[Type] = scalar
+	return
+}
+
+// registeredScalar returns the scalar that is registered for Type, or for the type that it points to; nil when there is none.
+// Types that implement GraphQLMarshaler or encoding.TextMarshaler, and are not registered as enums, are registered when they are first seen.
+func (tm *typeMapper) registeredScalar(Type reflect.Type) *graphql.Scalar {
+	if reflect.Ptr == Type.Kind() {
+		Type = Type.Elem()
+	}
+	if scalar, registered := tm.scalars[Type]; registered {
+		return scalar
+	}
+	if _, isEnum := tm.enums[Type]; isEnum {
+		return nil
+	}
+	if !marshals(Type) {
+		return nil
+	}
+	scalar := marshalerScalar(Type, tm.marshalerScalarName(Type))
+	if nil != scalar {
+		tm.scalars[Type] = scalar
+		log.Infof("%vdeclared scalar %v for %v", tm.indent(), scalar.Name(), Type)
+	}
+	return scalar
+}
+
+// pointsToScalar reports whether Type is a pointer to a registered scalar, or a list of them.
+// The scalars serialize the values that the pointers point to.
+func (tm *typeMapper) pointsToScalar(Type reflect.Type) bool {
+	if reflect.Slice == Type.Kind() || reflect.Array == Type.Kind() {
+		Type = Type.Elem()
+	}
+	return reflect.Ptr == Type.Kind() && nil != tm.registeredScalar(Type)
+}
+
+// resolveDereferenced returns a resolver that dereferences the pointer, or the list of pointers, that resolve returns.
+func resolveDereferenced(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if nil == resolve {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (result interface{}, err error) {
+		if result, err = resolve(p); nil != err {
+			return
+		}
+		return dereference(reflect.ValueOf(result)), nil
+	}
+}
+
+func dereference(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return value.Elem().Interface()
+	case reflect.Slice, reflect.Array:
+		if reflect.Slice == value.Kind() && value.IsNil() {
+			return nil
+		}
+		list := make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			list = append(list, dereference(value.Index(i)))
+		}
+		return list
+	}
+	return value.Interface()
+}
+
+// builtInScalarNames are the names of the scalars of the graphql specification.
+var builtInScalarNames = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true}
+
+// marshalerScalarName returns the name of the scalar of a type that marshals itself; the name of the type, or when that
+// is taken by a built-in scalar, another scalar or another type, the name qualified by the package, for example big_Int.
+func (tm *typeMapper) marshalerScalarName(Type reflect.Type) (name string) {
+	packagePath := strings.Split(Type.PkgPath(), "/")
+	candidates := []string{
+		Type.Name(),
+		reQualifier.ReplaceAllString(packagePath[len(packagePath)-1], "_") + "_" + Type.Name(),
+		reQualifier.ReplaceAllString(Type.PkgPath(), "_") + "_" + Type.Name(),
+	}
+	for _, name = range candidates {
+		if !tm.typeNameTaken(name) {
+			break
+		}
+	}
+	if name != Type.Name() {
+		log.Infof(`%vthe scalar of %v is named "%v"; "%v" is taken`, tm.indent(), Type, name, Type.Name())
+	}
+	return
+}
+
+// reQualifier matches the characters of package paths that graphql names cannot have.
+var reQualifier = regexp.MustCompile("[^_0-9A-Za-z]+")
+
+// typeNameTaken reports whether a built-in scalar, a registered scalar or a translated type is named name.
+func (tm *typeMapper) typeNameTaken(name string) bool {
+	if builtInScalarNames[name] {
+		return true
+	}
+	if _, taken := tm.graphqlTypes[name]; taken {
+		return true
+	}
+	for _, scalar := range tm.scalars {
+		if name == scalar.Name() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package gographql
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+type Celsius struct {
+	Degrees float64
+}
+
+// Reading is translated in place of Celsius by replaceTypeWith.
+type Reading struct {
+	Degrees float64
+}
+
+type Thermometer struct {
+	Current  Celsius
+	Previous *Celsius
+	History  []Celsius
+	Extremes [2]*Celsius
+	ByHour   map[int]Celsius
+	Hours    map[Celsius]int
+	Raw      Reading `replaceTypeWith:"Celsius"`
+}
+
+type ThermometerArgs struct {
+	Above  Celsius
+	Within []*Celsius
+}
+
+func (th Thermometer) Count(args ThermometerArgs) (count int) {
+	for _, c := range th.History {
+		if c.Degrees > args.Above.Degrees && (0 == len(args.Within) || c.Degrees <= args.Within[0].Degrees) {
+			count++
+		}
+	}
+	return
+}
+
+var celsiusType = reflect.TypeOf(Celsius{})
+
+var celsiusScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Celsius",
+	Serialize: func(value interface{}) interface{} {
+		v := reflect.Indirect(reflect.ValueOf(value))
+		if !v.IsValid() || !v.Type().ConvertibleTo(celsiusType) {
+			return nil
+		}
+		return v.Convert(celsiusType).Interface().(Celsius).Degrees
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if f, ok := value.(float64); ok {
+			return Celsius{Degrees: f}
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.FloatValue, *ast.IntValue:
+			if f, err := strconv.ParseFloat(valueAST.GetValue().(string), 64); nil == err {
+				return Celsius{Degrees: f}
+			}
+		}
+		return nil
+	},
+})
+
+func TestRegisteredScalarPositions(t *testing.T) {
+	tm := NewTypeMapper()
+	if err := tm.RegisterScalar(reflect.TypeOf(&Celsius{}), celsiusScalar); nil != err {
+		t.Fatal(err)
+	}
+	tm.SetTypeReplacer(replacer{"Celsius": celsiusType})
+	thermometer := Thermometer{
+		Current:  Celsius{Degrees: 20},
+		Previous: &Celsius{Degrees: 18.5},
+		History:  []Celsius{{Degrees: 10}, {Degrees: 30}},
+		Extremes: [2]*Celsius{{Degrees: -5}, nil},
+		ByHour:   map[int]Celsius{2: {Degrees: 12}},
+		Hours:    map[Celsius]int{{Degrees: 12}: 2},
+		Raw:      Reading{Degrees: 21},
+	}
+	schema, err := tm.NewSchemaBuilder().Query(thermometer).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if celsiusScalar != schema.Type("Celsius") || nil != schema.Type("Celsius_Input") {
+		t.Errorf("got %v and %v; expected Celsius to be the scalar, as an input too", schema.Type("Celsius"), schema.Type("Celsius_Input"))
+	}
+	for typeName, field := range map[string]string{"IntCelsiusEntry": "value", "CelsiusIntEntry": "key"} {
+		if entry, ok := schema.Type(typeName).(*graphql.Object); !ok || celsiusScalar != graphql.GetNamed(entry.Fields()[field].Type) {
+			t.Errorf("expected the %v of %v to be Celsius", field, typeName)
+		}
+	}
+	request := `{Current Previous History Extremes ByHour {key value} Hours {key value} Raw Count(Above: 5, Within: [20.5])}`
+	expected := `{"ByHour":[{"key":2,"value":12}],"Count":1,"Current":20,"Extremes":[-5,null],"History":[10,30],"Hours":[{"key":12,"value":2}],"Previous":18.5,"Raw":21}`
+	if data := execute(t, schema, request, nil); expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+	variables := map[string]interface{}{"above": 15.0}
+	if data, expected := execute(t, schema, `query($above: Celsius) {Count(Above: $above)}`, variables), `{"Count":1}`; expected != data {
+		t.Errorf("variables: got %v; expected %v", data, expected)
+	}
+}
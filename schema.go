@@ -0,0 +1,191 @@
+package gographql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// SchemaErrors holds all of the errors found while assembling a schema.
+type SchemaErrors []error
+
+// Error returns the errors joined into one string.
+func (se SchemaErrors) Error() string {
+	messages := make([]string, 0, len(se))
+	for _, err := range se {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// SchemaBuilder assembles a graphql.Schema from Go structs that are registered as roots of the
+// query, mutation and subscription operations.
+// The fields of every root registered for an operation become the fields of that operation's root type.
+// All roots are translated with the same type mapper and so a type that is shared by roots is declared once.
+type SchemaBuilder struct {
+	tm            *typeMapper
+	queries       []interface{}
+	mutations     []interface{}
+	subscriptions []interface{}
+}
+
+// NewSchemaBuilder creates a schema builder that uses the package's type mapper.
+func NewSchemaBuilder() *SchemaBuilder {
+	return objectMapper.NewSchemaBuilder()
+}
+
+// NewSchemaBuilder creates a schema builder that uses this type mapper.
+func (tm *typeMapper) NewSchemaBuilder() *SchemaBuilder {
+	return &SchemaBuilder{tm: tm}
+}
+
+// Query registers roots for the query operation.
+// A root is a Go struct value, a pointer to one, or its reflect.Type.
+// When a root is a value, rather than a reflect.Type, it is the source that its root fields are resolved from.
+func (sb *SchemaBuilder) Query(roots ...interface{}) *SchemaBuilder {
+	sb.queries = append(sb.queries, roots...)
+	return sb
+}
+
+// Mutation registers roots for the mutation operation.
+// See Query for what a root may be.
+func (sb *SchemaBuilder) Mutation(roots ...interface{}) *SchemaBuilder {
+	sb.mutations = append(sb.mutations, roots...)
+	return sb
+}
+
+// Subscription registers roots for the subscription operation.
+// See Query for what a root may be.
+func (sb *SchemaBuilder) Subscription(roots ...interface{}) *SchemaBuilder {
+	sb.subscriptions = append(sb.subscriptions, roots...)
+	return sb
+}
+
+// Build translates the registered roots and returns the validated schema.
+// All of the errors that are found are returned together as SchemaErrors.
+func (sb *SchemaBuilder) Build() (schema graphql.Schema, err error) {
+	var errs, rootErrs SchemaErrors
+	config := graphql.SchemaConfig{}
+	collectionFields, rootErrs := sb.tm.collectionFields()
+	errs = append(errs, rootErrs...)
+	config.Query, rootErrs = sb.rootObject("Query", sb.queries, collectionFields)
+	errs = append(errs, rootErrs...)
+	config.Mutation, rootErrs = sb.rootObject("Mutation", sb.mutations, nil)
+	errs = append(errs, rootErrs...)
+	config.Subscription, rootErrs = sb.rootObject("Subscription", sb.subscriptions, nil)
+	errs = append(errs, rootErrs...)
+	config.Types = sb.tm.ImplementationTypes()
+	typeErrs := append(sb.tm.interfaceErrors(), sb.tm.pageInfoErrors()...)
+	errs = append(errs, typeErrs...)
+	if nil == config.Query {
+		errs = append(errs, errors.New("no query root, or collection, was registered"))
+	} else if 0 == len(typeErrs) {
+		if schema, err = graphql.NewSchema(config); nil != err {
+			errs = append(errs, err)
+		}
+	}
+	if 0 != len(errs) {
+		err = errs
+		log.Error(err)
+	}
+	return
+}
+
+// rootObject merges the fields of the roots, and the generated fields, into one object type that is named operationName.
+// Returns nil when there are no roots and no generated fields.
+func (sb *SchemaBuilder) rootObject(operationName string, roots []interface{}, generated graphql.Fields) (root *graphql.Object, errs SchemaErrors) {
+	if 0 == len(roots) && 0 == len(generated) {
+		return
+	}
+	fields := graphql.Fields{}
+	declaredBy := map[string]string{}
+	for fieldName, field := range generated {
+		fields[fieldName] = field
+		declaredBy[fieldName] = "a registered collection"
+	}
+	for _, root := range roots {
+		if nil == root {
+			errs = append(errs, fmt.Errorf("%v root cannot be nil", operationName))
+			continue
+		}
+		object, err := sb.tm.GoToGraphqlOutput(root)
+		if nil != err {
+			errs = append(errs, fmt.Errorf("%v root %v: %v", operationName, rootName(root), err))
+			continue
+		}
+		_, isType := root.(reflect.Type)
+		objectFields := object.Fields()
+		for _, fieldName := range sortedFieldNames(objectFields) {
+			fieldDef := objectFields[fieldName]
+			if other, exists := declaredBy[fieldName]; exists {
+				errs = append(errs, fmt.Errorf(
+					`%v field "%v" is declared by both %v and %v`, operationName, fieldName, other, object.Name(),
+				))
+				continue
+			}
+			declaredBy[fieldName] = object.Name()
+			field := fieldFromDefinition(fieldDef)
+			if !isType {
+				field.Resolve = bindSource(root, field.Resolve)
+			}
+			fields[fieldName] = field
+		}
+	}
+	if 0 == len(fields) {
+		return
+	}
+	root = graphql.NewObject(graphql.ObjectConfig{Name: operationName, Fields: fields})
+	return
+}
+
+func rootName(root interface{}) string {
+	if Type, ok := root.(reflect.Type); ok {
+		return Type.String()
+	}
+	return reflect.TypeOf(root).String()
+}
+
+// bindSource returns a resolver that resolves from source rather than from the source given by graphql.
+func bindSource(source interface{}, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if nil == resolve {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		p.Source = source
+		return resolve(p)
+	}
+}
+
+// fieldFromDefinition returns the field configuration that produces fieldDef.
+func fieldFromDefinition(fieldDef *graphql.FieldDefinition) *graphql.Field {
+	args := graphql.FieldConfigArgument{}
+	for _, arg := range fieldDef.Args {
+		args[arg.Name()] = &graphql.ArgumentConfig{
+			Type:         arg.Type,
+			DefaultValue: arg.DefaultValue,
+			Description:  arg.Description(),
+		}
+	}
+	return &graphql.Field{
+		Name:              fieldDef.Name,
+		Type:              fieldDef.Type,
+		Args:              args,
+		Resolve:           fieldDef.Resolve,
+		Subscribe:         fieldDef.Subscribe,
+		DeprecationReason: fieldDef.DeprecationReason,
+		Description:       fieldDef.Description,
+	}
+}
+
+// sortedFieldNames returns the names of the fields in lexical order.
+func sortedFieldNames(fields graphql.FieldDefinitionMap) (names []string) {
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
@@ -0,0 +1,256 @@
+package gographql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// builtInScalars are the scalars that every schema has; they are not printed.
+var builtInScalars = map[string]bool{"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true}
+
+// PrintSchema returns the schema definition language (SDL) of the schema.
+// Types, fields and arguments are printed in order of their names.  Deprecated fields and enum values are printed with the @deprecated directive.
+func PrintSchema(schema graphql.Schema) string {
+	var sdl bytes.Buffer
+	if roots := schemaRoots(schema); "" != roots {
+		sdl.WriteString(roots)
+	}
+	typeMap := schema.TypeMap()
+	for _, typeName := range sortedTypeNames(typeMap) {
+		definition := printType(typeMap[typeName])
+		if "" == definition {
+			continue
+		}
+		if 0 != sdl.Len() {
+			sdl.WriteString("\n")
+		}
+		sdl.WriteString(definition)
+	}
+	return sdl.String()
+}
+
+// schemaRoots returns the schema definition when the roots are not named Query, Mutation and Subscription, otherwise "".
+func schemaRoots(schema graphql.Schema) string {
+	roots := []struct {
+		operation string
+		object    *graphql.Object
+	}{
+		{"query", schema.QueryType()},
+		{"mutation", schema.MutationType()},
+		{"subscription", schema.SubscriptionType()},
+	}
+	conventional := true
+	var definition bytes.Buffer
+	definition.WriteString("schema {\n")
+	for _, root := range roots {
+		if nil == root.object {
+			continue
+		}
+		if root.object.Name() != strings.Title(root.operation) {
+			conventional = false
+		}
+		fmt.Fprintf(&definition, "  %v: %v\n", root.operation, root.object.Name())
+	}
+	definition.WriteString("}\n")
+	if conventional {
+		return ""
+	}
+	return definition.String()
+}
+
+func printType(Type graphql.Type) string {
+	var definition bytes.Buffer
+	switch Type := Type.(type) {
+	case *graphql.Scalar:
+		if builtInScalars[Type.Name()] {
+			return ""
+		}
+		definition.WriteString(printDescription("", Type.Description()))
+		fmt.Fprintf(&definition, "scalar %v\n", Type.Name())
+	case *graphql.Object:
+		definition.WriteString(printDescription("", Type.PrivateDescription))
+		fmt.Fprintf(&definition, "type %v", Type.Name())
+		if interfaces := Type.Interfaces(); 0 != len(interfaces) {
+			names := make([]string, 0, len(interfaces))
+			for _, iface := range interfaces {
+				names = append(names, iface.Name())
+			}
+			fmt.Fprintf(&definition, " implements %v", strings.Join(names, " & "))
+		}
+		definition.WriteString(printFields(Type.Fields()))
+	case *graphql.Interface:
+		definition.WriteString(printDescription("", Type.Description()))
+		fmt.Fprintf(&definition, "interface %v", Type.Name())
+		definition.WriteString(printFields(Type.Fields()))
+	case *graphql.Union:
+		definition.WriteString(printDescription("", Type.Description()))
+		names := make([]string, 0, len(Type.Types()))
+		for _, member := range Type.Types() {
+			names = append(names, member.Name())
+		}
+		fmt.Fprintf(&definition, "union %v = %v\n", Type.Name(), strings.Join(names, " | "))
+	case *graphql.Enum:
+		definition.WriteString(printDescription("", Type.Description()))
+		fmt.Fprintf(&definition, "enum %v {\n", Type.Name())
+		for _, value := range sortedEnumValues(Type) {
+			definition.WriteString(printDescription("  ", value.Description))
+			fmt.Fprintf(&definition, "  %v%v\n", value.Name, printDeprecated(value.DeprecationReason))
+		}
+		definition.WriteString("}\n")
+	case *graphql.InputObject:
+		definition.WriteString(printDescription("", Type.Description()))
+		fmt.Fprintf(&definition, "input %v {\n", Type.Name())
+		fields := Type.Fields()
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field := fields[name]
+			definition.WriteString(printDescription("  ", field.Description()))
+			fmt.Fprintf(&definition, "  %v: %v%v\n", name, field.Type, printDefault(field.DefaultValue, field.Type))
+		}
+		definition.WriteString("}\n")
+	}
+	return definition.String()
+}
+
+// printFields returns the block of field definitions of an object or interface.
+func printFields(fields graphql.FieldDefinitionMap) string {
+	var block bytes.Buffer
+	block.WriteString(" {\n")
+	for _, name := range sortedFieldNames(fields) {
+		field := fields[name]
+		block.WriteString(printDescription("  ", field.Description))
+		fmt.Fprintf(&block, "  %v", name)
+		if 0 != len(field.Args) {
+			args := make([]string, 0, len(field.Args))
+			for _, arg := range field.Args {
+				args = append(args, fmt.Sprintf("%v: %v%v", arg.Name(), arg.Type, printDefault(arg.DefaultValue, arg.Type)))
+			}
+			sort.Strings(args)
+			fmt.Fprintf(&block, "(%v)", strings.Join(args, ", "))
+		}
+		fmt.Fprintf(&block, ": %v%v\n", field.Type, printDeprecated(field.DeprecationReason))
+	}
+	block.WriteString("}\n")
+	return block.String()
+}
+
+func printDescription(indent, description string) string {
+	if "" == description {
+		return ""
+	}
+	if !strings.Contains(description, "\n") {
+		return indent + quote(description) + "\n"
+	}
+	lines := strings.Split(strings.Replace(description, `"""`, `\"""`, -1), "\n")
+	return indent + `"""` + "\n" + indent + strings.Join(lines, "\n"+indent) + "\n" + indent + `"""` + "\n"
+}
+
+func printDeprecated(reason string) string {
+	if "" == reason {
+		return ""
+	}
+	return fmt.Sprintf(" @deprecated(reason: %v)", quote(reason))
+}
+
+func printDefault(value interface{}, Type graphql.Type) string {
+	if nil == value {
+		return ""
+	}
+	return " = " + printValue(value, Type)
+}
+
+// printValue returns the literal of a Go value of the graphql Type.
+func printValue(value interface{}, Type graphql.Type) string {
+	if nil == value {
+		return "null"
+	}
+	switch Type := Type.(type) {
+	case *graphql.NonNull:
+		return printValue(value, Type.OfType)
+	case *graphql.List:
+		list := reflect.ValueOf(value)
+		if reflect.Slice != list.Kind() && reflect.Array != list.Kind() {
+			return printValue(value, Type.OfType)
+		}
+		elements := make([]string, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			elements = append(elements, printValue(list.Index(i).Interface(), Type.OfType))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *graphql.Enum:
+		if name, ok := Type.Serialize(value).(string); ok {
+			return name
+		}
+	case *graphql.InputObject:
+		if object, ok := value.(map[string]interface{}); ok {
+			fields := Type.Fields()
+			names := make([]string, 0, len(object))
+			for name := range object {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			elements := make([]string, 0, len(names))
+			for _, name := range names {
+				var fieldType graphql.Type = JSON
+				if field, ok := fields[name]; ok {
+					fieldType = field.Type
+				}
+				elements = append(elements, fmt.Sprintf("%v: %v", name, printValue(object[name], fieldType)))
+			}
+			return "{" + strings.Join(elements, ", ") + "}"
+		}
+	case *graphql.Scalar:
+		return printLiteral(Type.Serialize(value))
+	}
+	return printLiteral(value)
+}
+
+// printLiteral returns the literal of a Go value that is made of strings, numbers, booleans, maps and slices.
+func printLiteral(value interface{}) string {
+	if nil == value {
+		return "null"
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.String:
+		return quote(reflected.String())
+	case reflect.Map:
+		keys := reflected.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return lessKey(keys[i], keys[j])
+		})
+		elements := make([]string, 0, len(keys))
+		for _, key := range keys {
+			elements = append(elements, fmt.Sprintf("%v: %v", key.Interface(), printLiteral(reflected.MapIndex(key).Interface())))
+		}
+		return "{" + strings.Join(elements, ", ") + "}"
+	case reflect.Slice, reflect.Array:
+		elements := make([]string, 0, reflected.Len())
+		for i := 0; i < reflected.Len(); i++ {
+			elements = append(elements, printLiteral(reflected.Index(i).Interface()))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	}
+	return fmt.Sprint(value)
+}
+
+// quote returns s as a graphql string literal.
+func quote(s string) string {
+	var quoted bytes.Buffer
+	encoder := json.NewEncoder(&quoted)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); nil != err {
+		return fmt.Sprintf("%q", s)
+	}
+	return strings.TrimSuffix(quoted.String(), "\n")
+}
@@ -0,0 +1,72 @@
+package gographql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Level int
+
+type Levels struct {
+	Debug   Level `enum:"DEBUG"`
+	Info    Level `enum:"INFO" description:"Routine events."`
+	Warn    Level `enum:"WARN"`
+	Error   Level `enum:"ERROR"`
+	Verbose Level `enum:"VERBOSE" deprecated:"Use DEBUG."`
+}
+
+type LogLine struct {
+	Message string
+	Level   Level
+	Origin  string `deprecated:"Read Source."`
+	Source  string
+}
+
+// logSchema returns a schema, built by a new mapper, whose query is a LogLine.
+func logSchema(t *testing.T) (schema graphql.Schema) {
+	tm := NewTypeMapper()
+	if err := tm.RegisterEnumStruct(Levels{Debug: 0, Info: 1, Warn: 2, Error: 3, Verbose: 4}); nil != err {
+		t.Fatal(err)
+	}
+	schema, err := tm.NewSchemaBuilder().Query(LogLine{}).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestPrintSchemaIsStable(t *testing.T) {
+	sdl := PrintSchema(logSchema(t))
+	expected := `enum Level {
+  DEBUG
+  ERROR
+  "Routine events."
+  INFO
+  VERBOSE @deprecated(reason: "Use DEBUG.")
+  WARN
+}
+`
+	if !strings.Contains(sdl, expected) {
+		t.Errorf("got\n%v\nexpected it to have\n%v", sdl, expected)
+	}
+	for i := 0; i < 20; i++ {
+		if again := PrintSchema(logSchema(t)); sdl != again {
+			t.Fatalf("the schema printed differently;\n%v\nand\n%v", sdl, again)
+		}
+	}
+}
+
+func TestDeprecationReport(t *testing.T) {
+	expected := []Deprecation{
+		{TypeName: "Level", Name: "VERBOSE", Reason: "Use DEBUG."},
+		{TypeName: "Query", Name: "Origin", Reason: "Read Source."},
+	}
+	for i := 0; i < 5; i++ {
+		if report := DeprecationReport(logSchema(t)); !reflect.DeepEqual(expected, report) {
+			t.Fatalf("got %v; expected %v", report, expected)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package gographql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// stdScalars returns the scalars for types of the standard library.
+func stdScalars() map[reflect.Type]*graphql.Scalar {
+	return map[reflect.Type]*graphql.Scalar{
+		reflect.TypeOf(time.Duration(0)): Duration,
+		reflect.TypeOf([]byte(nil)):      Bytes,
+		reflect.TypeOf(url.URL{}):        URL,
+		reflect.TypeOf(net.IP(nil)):      IP,
+	}
+}
+
+// parseString returns the string of a variable or of a String literal.
+func parseString(value interface{}) (text string, ok bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case *ast.StringValue:
+		return value.Value, true
+	}
+	return "", false
+}
+
+// newStringScalar returns a scalar that is serialized by serialize and parsed, from strings, by parse.
+// Both return nil for values that are not valid.
+func newStringScalar(name, description string, serialize func(value interface{}) interface{}, parse func(text string) interface{}) *graphql.Scalar {
+	parseValue := func(value interface{}) interface{} {
+		text, ok := parseString(value)
+		if !ok {
+			return nil
+		}
+		return parse(text)
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        name,
+		Description: description,
+		Serialize:   serialize,
+		ParseValue:  parseValue,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			return parseValue(valueAST)
+		},
+	})
+}
+
+var reISODuration = regexp.MustCompile(`^([-+])?P(?:([0-9.]+)W)?(?:([0-9.]+)D)?(?:T(?:([0-9.]+)H)?(?:([0-9.]+)M)?(?:([0-9.]+)S)?)?$`)
+
+// formatISODuration returns d as an ISO 8601 duration of hours, minutes and seconds; for example "PT1H2M3.5S".
+func formatISODuration(d time.Duration) string {
+	if 0 == d {
+		return "PT0S"
+	}
+	sign := ""
+	magnitude := uint64(d)
+	if d < 0 {
+		sign = "-"
+		magnitude = uint64(-d)
+	}
+	var iso strings.Builder
+	iso.WriteString(sign + "PT")
+	if hours := magnitude / uint64(time.Hour); 0 != hours {
+		fmt.Fprintf(&iso, "%vH", hours)
+	}
+	if minutes := magnitude % uint64(time.Hour) / uint64(time.Minute); 0 != minutes {
+		fmt.Fprintf(&iso, "%vM", minutes)
+	}
+	if nanoseconds := magnitude % uint64(time.Minute); 0 != nanoseconds {
+		seconds := strconv.FormatUint(nanoseconds/uint64(time.Second), 10)
+		if fraction := nanoseconds % uint64(time.Second); 0 != fraction {
+			seconds += strings.TrimRight(fmt.Sprintf(".%09d", fraction), "0")
+		}
+		iso.WriteString(seconds + "S")
+	}
+	return iso.String()
+}
+
+// parseDuration parses an ISO 8601 duration of weeks, days, hours, minutes and seconds, or a Go duration such as "1h2m3.5s".
+func parseDuration(text string) (d time.Duration, err error) {
+	text = strings.TrimSpace(text)
+	words := reISODuration.FindStringSubmatch(text)
+	if nil == words {
+		return time.ParseDuration(text)
+	}
+	if "" == strings.Join(words[2:], "") || strings.HasSuffix(text, "T") {
+		return 0, fmt.Errorf("%q has no duration", text)
+	}
+	units := []struct {
+		unit       string
+		multiplier time.Duration
+	}{{"h", 7 * 24}, {"h", 24}, {"h", 1}, {"m", 1}, {"s", 1}}
+	for i, unit := range units {
+		component := words[i+2]
+		if "" == component {
+			continue
+		}
+		parsed, err := time.ParseDuration(component + unit.unit)
+		if nil != err {
+			return 0, err
+		}
+		d += parsed * unit.multiplier
+	}
+	if "-" == words[1] {
+		d = -d
+	}
+	return
+}
+
+// Duration reflects the Go time.Duration to a graphql type and vice versa.  It is serialized as an ISO 8601 duration,
+// for example "PT1H30M", and parses ISO 8601 durations and Go durations, for example "1h30m".
+var Duration = newStringScalar(
+	"Duration", `A duration.  It is serialized as an ISO 8601 duration, for example "PT1H30M", and parsed from one or from a Go duration, for example "1h30m".`,
+	func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case time.Duration:
+			return formatISODuration(value)
+		case *time.Duration:
+			if nil != value {
+				return formatISODuration(*value)
+			}
+		}
+		return nil
+	},
+	func(text string) interface{} {
+		d, err := parseDuration(text)
+		if nil != err {
+			log.Infof("Duration: %v", err)
+			return nil
+		}
+		return d
+	},
+)
+
+// Bytes reflects the Go []byte to a graphql type and vice versa, as a base64 string.
+var Bytes = newStringScalar(
+	"Bytes", "Bytes, as a base64 string.",
+	func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case []byte:
+			if nil != value {
+				return base64.StdEncoding.EncodeToString(value)
+			}
+		case *[]byte:
+			if nil != value && nil != *value {
+				return base64.StdEncoding.EncodeToString(*value)
+			}
+		}
+		return nil
+	},
+	func(text string) interface{} {
+		for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+			if decoded, err := encoding.DecodeString(text); nil == err {
+				return decoded
+			}
+		}
+		log.Infof("Bytes: %q is not base64", text)
+		return nil
+	},
+)
+
+// URL reflects the Go url.URL to a graphql type and vice versa, as a string.
+var URL = newStringScalar(
+	"URL", "A URL.",
+	func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case url.URL:
+			return value.String()
+		case *url.URL:
+			if nil != value {
+				return value.String()
+			}
+		}
+		return nil
+	},
+	func(text string) interface{} {
+		parsed, err := url.Parse(text)
+		if nil != err {
+			log.Infof("URL: %v", err)
+			return nil
+		}
+		return *parsed
+	},
+)
+
+// IP reflects the Go net.IP to a graphql type and vice versa, as a string; for example "192.0.2.1" or "2001:db8::1".
+var IP = newStringScalar(
+	"IP", `An IP address; for example "192.0.2.1" or "2001:db8::1".`,
+	func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case net.IP:
+			if nil != value {
+				return value.String()
+			}
+		case *net.IP:
+			if nil != value && nil != *value {
+				return value.String()
+			}
+		}
+		return nil
+	},
+	func(text string) interface{} {
+		ip := net.ParseIP(strings.TrimSpace(text))
+		if nil == ip {
+			log.Infof("IP: %q is not an IP address", text)
+			return nil
+		}
+		return ip
+	},
+)
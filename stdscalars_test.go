@@ -0,0 +1,105 @@
+package gographql
+
+import (
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+func TestDuration(t *testing.T) {
+	d := 90 * time.Minute
+	testSerialize(t, Duration, []scalarTest{
+		{time.Duration(0), "PT0S"},
+		{d, "PT1H30M"},
+		{&d, "PT1H30M"},
+		{-(26*time.Hour + 3*time.Second + 500*time.Millisecond), "-PT26H3.5S"},
+		{time.Nanosecond, "PT0.000000001S"},
+		{(*time.Duration)(nil), nil},
+		{int64(5), nil},
+	})
+	testParse(t, Duration, []scalarTest{
+		{"PT1H30M", "PT1H30M"},
+		{"P1W", "PT168H"},
+		{"P1DT2H", "PT26H"},
+		{"PT0.5S", "PT0.5S"},
+		{"PT1.5M", "PT1M30S"},
+		{"-P1D", "-PT24H"},
+		{"+PT2S", "PT2S"},
+		{" PT2S ", "PT2S"},
+		{"1h30m", "PT1H30M"},
+		{"-1.5s", "-PT1.5S"},
+		{&ast.StringValue{Value: "P2D"}, "PT48H"},
+		{"P", nil},
+		{"PT", nil},
+		{"P1DT", nil},
+		{"P1Y", nil},
+		{"PT1.2.3S", nil},
+		{"PT1H30", nil},
+		{"ninety minutes", nil},
+		{5400, nil},
+		{&ast.IntValue{Value: "5400"}, nil},
+	})
+}
+
+func TestBytes(t *testing.T) {
+	data := []byte{0xfb, 0xff, 'a'}
+	testSerialize(t, Bytes, []scalarTest{
+		{data, "+/9h"},
+		{&data, "+/9h"},
+		{[]byte{}, ""},
+		{[]byte(nil), nil},
+		{"+/9h", nil},
+	})
+	testParse(t, Bytes, []scalarTest{
+		{"+/9h", "+/9h"},
+		{"aGk=", "aGk="},
+		{"aGk", "aGk="},
+		{"-_9h", "+/9h"},
+		{&ast.StringValue{Value: ""}, ""},
+		{"not base64!", nil},
+		{[]byte("aGk="), nil},
+	})
+}
+
+func TestURL(t *testing.T) {
+	parsed, err := url.Parse("https://user@example.com:8080/a%20b?q=1#top")
+	if nil != err {
+		t.Fatal(err)
+	}
+	testSerialize(t, URL, []scalarTest{
+		{*parsed, "https://user@example.com:8080/a%20b?q=1#top"},
+		{parsed, "https://user@example.com:8080/a%20b?q=1#top"},
+		{(*url.URL)(nil), nil},
+		{"https://example.com", nil},
+	})
+	testParse(t, URL, []scalarTest{
+		{"https://example.com/path?q=1", "https://example.com/path?q=1"},
+		{"/relative", "/relative"},
+		{&ast.StringValue{Value: "mailto:a@example.com"}, "mailto:a@example.com"},
+		{"http://[::1", nil},
+		{"%zz", nil},
+		{42, nil},
+	})
+}
+
+func TestIP(t *testing.T) {
+	ip := net.ParseIP("2001:db8::1")
+	testSerialize(t, IP, []scalarTest{
+		{net.ParseIP("192.0.2.1"), "192.0.2.1"},
+		{ip, "2001:db8::1"},
+		{&ip, "2001:db8::1"},
+		{net.IP(nil), nil},
+		{"192.0.2.1", nil},
+	})
+	testParse(t, IP, []scalarTest{
+		{"192.0.2.1", "192.0.2.1"},
+		{" 2001:DB8:0:0:0:0:0:1 ", "2001:db8::1"},
+		{&ast.StringValue{Value: "::ffff:192.0.2.1"}, "192.0.2.1"},
+		{"192.0.2.256", nil},
+		{"example.com", nil},
+		{&ast.IntValue{Value: "1"}, nil},
+	})
+}
@@ -0,0 +1,111 @@
+package gographql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// UnionTag is the name of the key for a field tag key/value pair where the value names a union that is
+// registered with RegisterUnion.  It applies to fields of an interface kind, or a list of them.
+var UnionTag = "union"
+
+// RegisterUnion declares a graphql union of the output types of the members.
+// Fields that are tagged with the union's name, and fields of a Go interface type that has the union's name,
+// are translated to the union.  The member of a value is chosen from the value's dynamic Go type.
+// A member is a struct value, a pointer to one, or its reflect.Type.
+func RegisterUnion(name string, members ...interface{}) (err error) {
+	return objectMapper.RegisterUnion(name, members...)
+}
+
+// RegisterUnion declares a graphql union of the output types of the members.
+// Fields that are tagged with the union's name, and fields of a Go interface type that has the union's name,
+// are translated to the union.  The member of a value is chosen from the value's dynamic Go type.
+// A member is a struct value, a pointer to one, or its reflect.Type.
+func (tm *typeMapper) RegisterUnion(name string, members ...interface{}) (err error) {
+	if "" == name {
+		err = errors.New(`union name cannot be ""`)
+		log.Error(err)
+		return
+	}
+	if 0 == len(members) {
+		err = fmt.Errorf(`union "%v" must have at least one member`, name)
+		log.Error(err)
+		return
+	}
+	memberTypes := make([]reflect.Type, 0, len(members))
+	for _, member := range members {
+		memberType := typeOf(member)
+		if nil == memberType || reflect.Struct != memberType.Kind() {
+			err = fmt.Errorf(`member %v of union "%v" is not a struct`, memberType, name)
+			log.Error(err)
+			return
+		}
+		memberTypes = append(memberTypes, memberType)
+	}
+	tm.unions[name] = memberTypes
+	return
+}
+
+// goUnionToGraphqlType returns the union that is declared for the field, or nil when there is none.
+func (tm *typeMapper) goUnionToGraphqlType(Type reflect.Type, structField reflect.StructField) (union *graphql.Union, err error) {
+	name := structField.Tag.Get(UnionTag)
+	if "" == name {
+		name = Type.Name()
+	}
+	members, registered := tm.unions[name]
+	if !registered {
+		if "" != structField.Tag.Get(UnionTag) {
+			err = fmt.Errorf(`union "%v" is not registered`, name)
+		}
+		return
+	}
+	if union, defined := tm.graphqlTypes[name].(*graphql.Union); defined {
+		return union, nil
+	}
+	objects := make([]*graphql.Object, 0, len(members))
+	memberNames := map[reflect.Type]string{}
+	for _, member := range members {
+		// a member that is being translated is a stub until the translation is done.
+		graphqlType, err := tm.goToGraphqlType(member)
+		if nil != err {
+			return nil, err
+		}
+		object, ok := graphqlType.(*graphql.Object)
+		if !ok {
+			return nil, fmt.Errorf(`member %v of union "%v" is not an object`, member, name)
+		}
+		objects = append(objects, object)
+		memberNames[member] = member.Name()
+	}
+	// a member may have declared the union while being translated.
+	if union, defined := tm.graphqlTypes[name].(*graphql.Union); defined {
+		return union, nil
+	}
+	graphqlTypes := tm.graphqlTypes
+	union = graphql.NewUnion(graphql.UnionConfig{
+		Name:  name,
+		Types: objects,
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			valueType := typeOf(p.Value)
+			if nil == valueType {
+				return nil
+			}
+			memberName, ok := memberNames[valueType]
+			if !ok {
+				log.Errorf(`%v is not a member of union "%v"`, valueType, name)
+				return nil
+			}
+			object, _ := graphqlTypes[memberName].(*graphql.Object)
+			return object
+		},
+	})
+	if err = union.Error(); nil != err {
+		return nil, err
+	}
+	tm.graphqlTypes[name] = union
+	log.Infof("%vdeclared union %v", tm.indent(), name)
+	return
+}
@@ -0,0 +1,59 @@
+package gographql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+type Article struct {
+	Title string
+	Words int
+}
+
+type Video struct {
+	Title   string
+	Seconds int
+}
+
+type Feed struct {
+	Items  []interface{} `union:"Media"`
+	Pinned interface{}   `union:"Media"`
+}
+
+// feedSchema returns a schema whose query is the feed, having the union Media of Article and Video.
+func feedSchema(t *testing.T, feed Feed) (schema graphql.Schema) {
+	tm := NewTypeMapper()
+	if err := tm.RegisterUnion("Media", Article{}, &Video{}); nil != err {
+		t.Fatal(err)
+	}
+	schema, err := tm.NewSchemaBuilder().Query(feed).Build()
+	if nil != err {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestUnionFragments(t *testing.T) {
+	schema := feedSchema(t, Feed{
+		Items:  []interface{}{Article{Title: "a", Words: 300}, &Video{Title: "v", Seconds: 60}},
+		Pinned: Video{Title: "p", Seconds: 5},
+	})
+	request := `{
+		Items {__typename ... on Article {Title Words} ... on Video {Title Seconds}}
+		Pinned {... on Video {Seconds}}
+	}`
+	expected := `{"Items":[{"Title":"a","Words":300,"__typename":"Article"},{"Seconds":60,"Title":"v","__typename":"Video"}],"Pinned":{"Seconds":5}}`
+	if data := execute(t, schema, request, nil); expected != data {
+		t.Errorf("got %v; expected %v", data, expected)
+	}
+}
+
+func TestUnionNonMember(t *testing.T) {
+	schema := feedSchema(t, Feed{Pinned: Location{City: "Oslo"}})
+	message := executeError(t, schema, `{Pinned {... on Article {Title}}}`, nil)
+	if !strings.Contains(message, "Media") {
+		t.Errorf("got %q; expected an error that the value is not a member of Media", message)
+	}
+}